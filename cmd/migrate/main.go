@@ -0,0 +1,122 @@
+// Command migrate applies the embedded schema migrations.
+//
+//	migrate [-db-driver mysql|postgres|sqlite] [-dsn DSN] up | down [n] | status | to <version>
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"go-breeders/internal/database"
+	"go-breeders/internal/migrate"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+func main() {
+	driver := flag.String("db-driver", "mysql", "Database driver (mysql, postgres or sqlite)")
+	dsn := flag.String("dsn", "", "DSN; a sqlite:// or postgres:// scheme selects the driver (defaults to the docker-compose database)")
+	timeout := flag.Duration("timeout", 5*time.Minute, "Deadline for the whole run")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: migrate [flags] up | down [n] | status | to <version>\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	driverName, dataSource, err := database.Resolve(*driver, *dsn)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	dialect, err := migrate.ForDriver(driverName)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	db, err := sql.Open(driverName, dataSource)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	m, err := migrate.New(db, dialect)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	if err := run(ctx, m, flag.Args()); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(ctx context.Context, m *migrate.Migrator, args []string) error {
+	switch args[0] {
+	case "up":
+		n, err := m.Up(ctx)
+		fmt.Printf("applied %d migration(s)\n", n)
+		return err
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			v, err := strconv.Atoi(args[1])
+			if err != nil || v < 1 {
+				return fmt.Errorf("down: invalid step count %q", args[1])
+			}
+			steps = v
+		}
+		n, err := m.Down(ctx, steps)
+		fmt.Printf("reverted %d migration(s)\n", n)
+		return err
+	case "to":
+		if len(args) < 2 {
+			return fmt.Errorf("to: missing version")
+		}
+		version, err := strconv.Atoi(args[1])
+		if err != nil || version < 0 {
+			return fmt.Errorf("to: invalid version %q", args[1])
+		}
+		n, err := m.To(ctx, version)
+		fmt.Printf("ran %d migration(s)\n", n)
+		return err
+	case "status":
+		return status(ctx, m)
+	default:
+		return fmt.Errorf("unknown command %q", args[0])
+	}
+}
+
+func status(ctx context.Context, m *migrate.Migrator) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATE\tAPPLIED AT")
+	for _, s := range statuses {
+		state, at := "pending", ""
+		switch {
+		case s.Dirty:
+			state = "dirty"
+		case s.Applied:
+			state = "applied"
+		}
+		if s.Applied {
+			at = s.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", s.Version, s.Name, state, at)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"errors"
+	"go-breeders/internal/apperr"
+	"go-breeders/internal/user"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// Account pages
+const (
+	loginPage          = "login.page.gohtml"
+	logoutPage         = "logout.page.gohtml"
+	registerPage       = "register.page.gohtml"
+	forgotPasswordPage = "forgot-password.page.gohtml"
+	resetPasswordPage  = "reset-password.page.gohtml"
+	messagePage        = "message.page.gohtml"
+)
+
+// ShowLogin renders the login form
+func (app *application) ShowLogin(w http.ResponseWriter, r *http.Request) {
+	app.render(w, r, loginPage, &temmplateData{Data: map[string]any{
+		"next": localPath(r.URL.Query().Get("next")),
+	}})
+}
+
+// Login logs in with the submitted form and redirects to the page the user
+// came from, or shows the form again with an error
+func (app *application) Login(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		app.renderStatus(w, r, http.StatusBadRequest, loginPage, nil)
+		return
+	}
+	email, next := r.PostForm.Get("email"), localPath(r.PostForm.Get("next"))
+
+	token, session, err := app.users.Login(r.Context(), email, r.PostForm.Get("password"))
+	if apperr.Is(err, apperr.Unauthorized) {
+		app.renderStatus(w, r, http.StatusUnauthorized, loginPage, &temmplateData{Data: map[string]any{
+			"error": "Invalid email or password.",
+			"email": email,
+			"next":  next,
+		}})
+		return
+	}
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.users.SetCookie(w, r, token, session.Expiry)
+	http.Redirect(w, r, next, http.StatusSeeOther)
+}
+
+// ShowLogout asks the user to confirm logging out
+func (app *application) ShowLogout(w http.ResponseWriter, r *http.Request) {
+	app.render(w, r, logoutPage, nil)
+}
+
+// Logout ends the session and returns to the login page
+func (app *application) Logout(w http.ResponseWriter, r *http.Request) {
+	if c, err := r.Cookie(user.CookieName); err == nil {
+		if err := app.users.Logout(r.Context(), c.Value); err != nil {
+			app.serverError(w, err)
+			return
+		}
+	}
+	app.users.ClearCookie(w, r)
+	http.Redirect(w, r, "/login", http.StatusSeeOther)
+}
+
+// localPath returns next if it is a path on this site, or "/". It keeps the
+// login form from redirecting to other hosts: browsers drop tabs and line
+// breaks and read "\" as "/", so "/\t/evil.com" would lead to evil.com.
+func localPath(next string) string {
+	u, err := url.Parse(next)
+	if err != nil || u.Scheme != "" || u.Host != "" || u.Opaque != "" || u.User != nil ||
+		!strings.HasPrefix(u.Path, "/") || strings.HasPrefix(u.Path, "//") ||
+		unsafeInPath(next) || unsafeInPath(u.Path) {
+		return "/"
+	}
+	return next
+}
+
+// unsafeInPath reports whether s holds a backslash, whitespace or a control character
+func unsafeInPath(s string) bool {
+	return strings.IndexFunc(s, func(r rune) bool {
+		return r == '\\' || unicode.IsSpace(r) || unicode.IsControl(r)
+	}) >= 0
+}
+
+// ShowRegister renders the sign-up form
+func (app *application) ShowRegister(w http.ResponseWriter, r *http.Request) {
+	app.render(w, r, registerPage, &temmplateData{Data: map[string]any{"errors": map[string]string{}}})
+}
+
+// Register creates an inactive account and asks the visitor to check their email
+func (app *application) Register(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		app.renderStatus(w, r, http.StatusBadRequest, registerPage, &temmplateData{Data: map[string]any{"errors": map[string]string{}}})
+		return
+	}
+	reg := user.Registration{
+		FirstName: strings.TrimSpace(r.PostForm.Get("first_name")),
+		LastName:  strings.TrimSpace(r.PostForm.Get("last_name")),
+		Email:     strings.TrimSpace(r.PostForm.Get("email")),
+		Password:  r.PostForm.Get("password"),
+	}
+
+	err := app.accounts.Register(r.Context(), reg)
+	if apperr.Is(err, apperr.Validation) {
+		app.renderStatus(w, r, http.StatusUnprocessableEntity, registerPage, &temmplateData{Data: map[string]any{
+			"errors":     fieldErrors(err),
+			"first_name": reg.FirstName,
+			"last_name":  reg.LastName,
+			"email":      reg.Email,
+		}})
+		return
+	}
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.message(w, r, http.StatusOK, "Check your email",
+		"We sent an email to "+reg.Email+". Follow the link in it to finish creating your account.", "", "")
+}
+
+// Activate activates the account named by the signed ?token= of an activation link
+func (app *application) Activate(w http.ResponseWriter, r *http.Request) {
+	_, err := app.accounts.Activate(r.Context(), r.URL.Query().Get("token"))
+	if apperr.Is(err, apperr.BadRequest) {
+		app.message(w, r, http.StatusBadRequest, "Link expired",
+			"This activation link is invalid or has expired. Ask for a new one by resetting your password.",
+			"/forgot-password", "Send a new link")
+		return
+	}
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.message(w, r, http.StatusOK, "Account activated", "Your account is ready.", "/login", "Log in")
+}
+
+// ShowForgotPassword renders the form that asks for a password reset link
+func (app *application) ShowForgotPassword(w http.ResponseWriter, r *http.Request) {
+	app.render(w, r, forgotPasswordPage, nil)
+}
+
+// ForgotPassword emails a reset link. The answer is the same whether or not
+// the email has an account.
+func (app *application) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		app.renderStatus(w, r, http.StatusBadRequest, forgotPasswordPage, nil)
+		return
+	}
+
+	email := strings.TrimSpace(r.PostForm.Get("email"))
+	if err := app.accounts.RequestPasswordReset(r.Context(), email); err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.message(w, r, http.StatusOK, "Check your email",
+		"If "+email+" belongs to an account, we sent it a link to choose a new password.", "", "")
+}
+
+// ShowResetPassword renders the new password form for a valid reset link
+func (app *application) ShowResetPassword(w http.ResponseWriter, r *http.Request) {
+	token := r.URL.Query().Get("token")
+	_, err := app.accounts.CheckResetToken(r.Context(), token)
+	if apperr.Is(err, apperr.BadRequest) {
+		app.badResetLink(w, r)
+		return
+	}
+	if err != nil {
+		app.serverError(w, err)
+		return
+	}
+
+	app.render(w, r, resetPasswordPage, &temmplateData{Data: map[string]any{
+		"token":  token,
+		"errors": map[string]string{},
+	}})
+}
+
+// ResetPassword sets the password chosen through a reset link
+func (app *application) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		app.badResetLink(w, r)
+		return
+	}
+
+	token := r.PostForm.Get("token")
+	err := app.accounts.ResetPassword(r.Context(), token, r.PostForm.Get("password"))
+	switch {
+	case apperr.Is(err, apperr.Validation):
+		app.renderStatus(w, r, http.StatusUnprocessableEntity, resetPasswordPage, &temmplateData{Data: map[string]any{
+			"token":  token,
+			"errors": fieldErrors(err),
+		}})
+		return
+	case apperr.Is(err, apperr.BadRequest):
+		app.badResetLink(w, r)
+		return
+	case err != nil:
+		app.serverError(w, err)
+		return
+	}
+
+	// the reset ended every session, including this browser's
+	app.users.ClearCookie(w, r)
+	app.message(w, r, http.StatusOK, "Password changed", "Log in with your new password.", "/login", "Log in")
+}
+
+// badResetLink explains that a password reset link cannot be used
+func (app *application) badResetLink(w http.ResponseWriter, r *http.Request) {
+	app.message(w, r, http.StatusBadRequest, "Link expired",
+		"This password reset link is invalid, has expired or was already used.",
+		"/forgot-password", "Send a new link")
+}
+
+// message renders the message page with an optional link button
+func (app *application) message(w http.ResponseWriter, r *http.Request, status int, title, text, link, linkText string) {
+	app.renderStatus(w, r, status, messagePage, &temmplateData{Data: map[string]any{
+		"title":     title,
+		"message":   text,
+		"link":      link,
+		"link_text": linkText,
+	}})
+}
+
+// fieldErrors returns the first message for each invalid field in err
+func fieldErrors(err error) map[string]string {
+	fields := make(map[string]string)
+	var e *apperr.Error
+	if errors.As(err, &e) {
+		for _, f := range e.Fields {
+			if _, ok := fields[f.Field]; !ok {
+				fields[f.Field] = f.Message
+			}
+		}
+	}
+	return fields
+}
@@ -1,33 +1,125 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
+	"go-breeders/internal/breeder"
+	"go-breeders/internal/cat"
+	"go-breeders/internal/database"
+	"go-breeders/internal/dog"
+	"go-breeders/internal/migrate"
+	"go-breeders/internal/uow"
+	"go-breeders/internal/user"
+	"log"
 	"time"
-
-	_ "github.com/go-sql-driver/mysql"
 )
 
-const (
-	maxOpenDbConn = 25
-	maxIdleDBConn = 25
-	maxDBlifetime = 5 * time.Minute
-)
+// memoryDriver selects the in-memory repositories instead of a database
+const memoryDriver = "memory"
+
+// repositories holds one Repository per domain for the selected backend and
+// the unit of work that spans them
+type repositories struct {
+	uow.Repositories
+	work uow.UnitOfWork
+}
+
+// openRepositories connects to the configured backend and builds the
+// repositories on it. The memory backend needs no database and starts from
+// the mock fixtures.
+func (app *application) openRepositories() (repositories, error) {
+	if app.config.dbDriver == memoryDriver {
+		breeders := breeder.NewMemoryRepository(breeder.MockFixtures())
+		repos := uow.Repositories{
+			Breeders: breeders,
+			Dogs:     dog.NewMemoryRepository(breeders, dog.MockFixtures()),
+			Cats:     cat.NewMemoryRepository(breeders, cat.MockFixtures()),
+			Users:    user.NewMemoryRepository(user.MockFixtures()),
+		}
+		return repositories{Repositories: repos, work: uow.NewMemory(repos)}, nil
+	}
 
-func initMySQLDB(dsn string) (*sql.DB, error) {
-	db, err := sql.Open("mysql", dsn)
+	driver, dsn, err := database.Resolve(app.config.dbDriver, app.config.dsn)
+	if err != nil {
+		return repositories{}, err
+	}
+	app.config.dbDriver = driver
 
+	db, err := database.Open(context.Background(), driver, dsn, app.config.pool)
 	if err != nil {
-		return nil, err
+		return repositories{}, err
 	}
+	app.db = db
 
-	//test our database
-	if err = db.Ping(); err != nil {
-		return nil, err
+	// a SQLite file starts empty, so it always gets the schema and breed data
+	if app.config.migrate || driver == database.SQLite {
+		if err := runMigrations(driver, db); err != nil {
+			return repositories{}, err
+		}
 	}
 
-	db.SetMaxIdleConns(maxOpenDbConn)
-	db.SetMaxIdleConns(maxIdleDBConn)
-	db.SetConnMaxLifetime(maxDBlifetime)
+	bind, err := binder(driver, app.config.queryTimeout)
+	if err != nil {
+		return repositories{}, err
+	}
+	return repositories{Repositories: bind(db), work: uow.NewSQL(db, bind)}, nil
+}
 
-	return db, nil
+// binder returns the function that builds the repositories for driver on
+// top of a database or transaction
+func binder(driver string, queryTimeout time.Duration) (uow.Binder, error) {
+	switch driver {
+	case database.MySQL:
+		return func(db database.DBTX) uow.Repositories {
+			return uow.Repositories{
+				Breeders: breeder.NewMySQLRepository(db, queryTimeout),
+				Dogs:     dog.NewMySQLRepository(db, queryTimeout),
+				Cats:     cat.NewMySQLRepository(db, queryTimeout),
+				Users:    user.NewMySQLRepository(db, queryTimeout),
+			}
+		}, nil
+	case database.Postgres:
+		return func(db database.DBTX) uow.Repositories {
+			return uow.Repositories{
+				Breeders: breeder.NewPostgresRepository(db, queryTimeout),
+				Dogs:     dog.NewPostgresRepository(db, queryTimeout),
+				Cats:     cat.NewPostgresRepository(db, queryTimeout),
+				Users:    user.NewPostgresRepository(db, queryTimeout),
+			}
+		}, nil
+	case database.SQLite:
+		return func(db database.DBTX) uow.Repositories {
+			return uow.Repositories{
+				Breeders: breeder.NewSQLiteRepository(db, queryTimeout),
+				Dogs:     dog.NewSQLiteRepository(db, queryTimeout),
+				Cats:     cat.NewSQLiteRepository(db, queryTimeout),
+				Users:    user.NewSQLiteRepository(db, queryTimeout),
+			}
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported database driver %q", driver)
+}
+
+// runMigrations brings the schema up to date before the server starts.
+func runMigrations(driver string, db *sql.DB) error {
+	dialect, err := migrate.ForDriver(driver)
+	if err != nil {
+		return err
+	}
+
+	m, err := migrate.New(db, dialect)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	n, err := m.Up(ctx)
+	if err != nil {
+		return err
+	}
+	log.Printf("applied %d migration(s)", n)
+	return nil
 }
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Environment variables that set the defaults of the database pool flags
+const (
+	envDBMaxOpen      = "BREEDERS_DB_MAX_OPEN"
+	envDBMaxIdle      = "BREEDERS_DB_MAX_IDLE"
+	envDBMaxLifetime  = "BREEDERS_DB_MAX_LIFETIME"
+	envDBMaxIdleTime  = "BREEDERS_DB_MAX_IDLE_TIME"
+	envDBPingAttempts = "BREEDERS_DB_PING_ATTEMPTS"
+	envDBPingBackoff  = "BREEDERS_DB_PING_BACKOFF"
+)
+
+// Environment variables holding secrets, which should not appear in process listings
+const (
+	envSecret       = "BREEDERS_SECRET"
+	envSMTPPassword = "BREEDERS_SMTP_PASSWORD"
+)
+
+// envInt returns the integer in the environment variable name, or fallback
+// when it is unset. A malformed value stops the program instead of being ignored.
+func envInt(name string, fallback int) int {
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		return fallback
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		log.Fatalf("invalid %s %q: %v", name, v, err)
+	}
+	return n
+}
+
+// envDuration is envInt for durations such as "90s" or "5m"
+func envDuration(name string, fallback time.Duration) time.Duration {
+	v, ok := os.LookupEnv(name)
+	if !ok {
+		return fallback
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Fatalf("invalid %s %q: %v", name, v, err)
+	}
+	return d
+}
@@ -1,7 +1,8 @@
 package main
 
 import (
-	"fmt"
+	"go-breeders/internal/apperr"
+	"go-breeders/internal/cache"
 	"go-breeders/pets"
 	"net/http"
 
@@ -10,13 +11,18 @@ import (
 )
 
 func (app *application) ShowHome(w http.ResponseWriter, r *http.Request) {
-	app.render(w, "home.page.gohtml", nil)
+	app.render(w, r, "home.page.gohtml", nil)
 
 }
 
+// ShowPage renders the registered page named by the {page} URL param, or the 404 page
 func (app *application) ShowPage(w http.ResponseWriter, r *http.Request) {
-	page := chi.URLParam(r, "page")
-	app.render(w, fmt.Sprintf("%s.page.gohtml", page), nil)
+	t, ok := pages[chi.URLParam(r, "page")]
+	if !ok {
+		app.notFound(w, r)
+		return
+	}
+	app.render(w, r, t, nil)
 }
 
 func (app *application) CreateDogFromFactory(w http.ResponseWriter, r *http.Request) {
@@ -32,7 +38,7 @@ func (app *application) CreateCatFromFactory(w http.ResponseWriter, r *http.Requ
 }
 
 func (app *application) TestPatterns(w http.ResponseWriter, r *http.Request) {
-	app.render(w, "test.page.gohtml", nil)
+	app.render(w, r, "test.page.gohtml", nil)
 }
 
 func (app *application) CreateDogFromAbstractFactory(w http.ResponseWriter, r *http.Request) {
@@ -54,3 +60,69 @@ func (app *application) CreateCatFromAbstractFactory(w http.ResponseWriter, r *h
 	}
 	_ = t.WriteJSON(w, http.StatusOK, cat)
 }
+
+// dbStats is the JSON body of /debug/db: the configured pool limits and the
+// live sql.DBStats counters
+type dbStats struct {
+	Driver             string `json:"driver"`
+	MaxOpenConnections int    `json:"max_open_connections"`
+	MaxIdleConnections int    `json:"max_idle_connections"`
+	MaxLifetime        string `json:"max_lifetime"`
+	MaxIdleTime        string `json:"max_idle_time"`
+	OpenConnections    int    `json:"open_connections"`
+	InUse              int    `json:"in_use"`
+	Idle               int    `json:"idle"`
+	WaitCount          int64  `json:"wait_count"`
+	WaitDuration       string `json:"wait_duration"`
+	MaxIdleClosed      int64  `json:"max_idle_closed"`
+	MaxIdleTimeClosed  int64  `json:"max_idle_time_closed"`
+	MaxLifetimeClosed  int64  `json:"max_lifetime_closed"`
+}
+
+// DBStats reports the database connection pool statistics as JSON
+func (app *application) DBStats(w http.ResponseWriter, r *http.Request) {
+	var t toolbox.Tools
+
+	if app.db == nil {
+		apperr.WriteJSON(w, apperr.NotFoundf("the %s backend has no connection pool", app.config.dbDriver))
+		return
+	}
+
+	stats := app.db.Stats()
+	// database/sql never keeps more idle connections than it may open
+	maxIdle := app.config.pool.MaxIdle
+	if stats.MaxOpenConnections > 0 {
+		maxIdle = min(maxIdle, stats.MaxOpenConnections)
+	}
+
+	_ = t.WriteJSON(w, http.StatusOK, dbStats{
+		Driver:             app.config.dbDriver,
+		MaxOpenConnections: stats.MaxOpenConnections,
+		MaxIdleConnections: maxIdle,
+		MaxLifetime:        app.config.pool.MaxLifetime.String(),
+		MaxIdleTime:        app.config.pool.MaxIdleTime.String(),
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDuration:       stats.WaitDuration.String(),
+		MaxIdleClosed:      stats.MaxIdleClosed,
+		MaxIdleTimeClosed:  stats.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+	})
+}
+
+// CacheStats reports the hit and miss counters of the breed caches as JSON
+func (app *application) CacheStats(w http.ResponseWriter, r *http.Request) {
+	var t toolbox.Tools
+
+	stats := make(map[string]cache.Stats)
+	if app.dogBreedCache != nil {
+		stats["dog_breeds"] = app.dogBreedCache.BreedCacheStats()
+	}
+	if app.catBreedCache != nil {
+		stats["cat_breeds"] = app.catBreedCache.BreedCacheStats()
+	}
+
+	_ = t.WriteJSON(w, http.StatusOK, stats)
+}
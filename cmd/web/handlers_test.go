@@ -1,9 +1,31 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"go-breeders/internal/breeder"
+	"go-breeders/internal/database"
+	"go-breeders/internal/dog"
+	"go-breeders/internal/list"
+	"go-breeders/internal/mailer/mailertest"
+	"go-breeders/internal/search"
+	"go-breeders/internal/user"
+	"go-breeders/internal/view"
+	"go-breeders/templates"
+	"io/fs"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/go-chi/chi/v5"
 )
 
 func TestApplication_GetAllDogBreedsJSON(t *testing.T) {
@@ -41,3 +63,1188 @@ func TestApplication_GetAllDogBreedsJSON(t *testing.T) {
 	// Log the response for debugging
 	t.Log("Response:", rr.Body.String())
 }
+
+func TestApplication_DogWriteAPI(t *testing.T) {
+	routes := asUser(user.Admin, testApp.routes())
+
+	tests := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		expectedStatus int
+		expectedHeader string
+	}{
+		{"create", "POST", "/api/dogs", `{"dog_name":"Rex","breed_id":2,"breeder_id":1,"weight":70,"date_of_birth":"2022-04-01T00:00:00Z"}`, http.StatusCreated, "/api/dogs/999"},
+		{"create bad json", "POST", "/api/dogs", `{"dog_name":`, http.StatusBadRequest, ""},
+		{"create unknown field", "POST", "/api/dogs", `{"name":"Rex"}`, http.StatusBadRequest, ""},
+		{"create missing name", "POST", "/api/dogs", `{"breed_id":2,"weight":70,"date_of_birth":"2022-04-01T00:00:00Z"}`, http.StatusUnprocessableEntity, ""},
+		{"create overweight", "POST", "/api/dogs", `{"dog_name":"Tiny","breed_id":1,"weight":40,"date_of_birth":"2022-04-01T00:00:00Z"}`, http.StatusUnprocessableEntity, ""},
+		{"create future birth", "POST", "/api/dogs", `{"dog_name":"Rex","breed_id":2,"weight":70,"date_of_birth":"2999-01-01T00:00:00Z"}`, http.StatusUnprocessableEntity, ""},
+		{"create unknown breed", "POST", "/api/dogs", `{"dog_name":"Rex","breed_id":42,"weight":70,"date_of_birth":"2022-04-01T00:00:00Z"}`, http.StatusUnprocessableEntity, ""},
+		{"create unknown breeder", "POST", "/api/dogs", `{"dog_name":"Rex","breed_id":2,"breeder_id":42,"weight":70,"date_of_birth":"2022-04-01T00:00:00Z"}`, http.StatusUnprocessableEntity, ""},
+		{"replace", "PUT", "/api/dogs/1", `{"dog_name":"Max","breed_id":2,"breeder_id":1,"weight":76,"date_of_birth":"2020-01-15T00:00:00Z"}`, http.StatusOK, ""},
+		{"replace missing", "PUT", "/api/dogs/42", `{"dog_name":"Ghost"}`, http.StatusNotFound, ""},
+		{"replace invalid id", "PUT", "/api/dogs/abc", `{"dog_name":"Ghost"}`, http.StatusBadRequest, ""},
+		{"patch", "PATCH", "/api/dogs/2", `{"weight":6}`, http.StatusOK, ""},
+		{"patch missing", "PATCH", "/api/dogs/42", `{"weight":6}`, http.StatusNotFound, ""},
+		{"delete", "DELETE", "/api/dogs/1", "", http.StatusNoContent, ""},
+		{"delete missing", "DELETE", "/api/dogs/42", "", http.StatusNotFound, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rr := httptest.NewRecorder()
+
+			routes.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("%s %s returned wrong status code: got %v want %v (body: %s)",
+					tt.method, tt.url, rr.Code, tt.expectedStatus, rr.Body.String())
+			}
+
+			if tt.expectedHeader != "" && rr.Header().Get("Location") != tt.expectedHeader {
+				t.Errorf("wrong Location header: got %q want %q",
+					rr.Header().Get("Location"), tt.expectedHeader)
+			}
+		})
+	}
+}
+
+func TestApplication_CatWriteAPI(t *testing.T) {
+	routes := asUser(user.Admin, testApp.routes())
+
+	tests := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		expectedStatus int
+		expectedHeader string
+	}{
+		{"create", "POST", "/api/cats", `{"cat_name":"Tom","breed_id":1,"breeder_id":1,"weight":9,"date_of_birth":"2023-06-01T00:00:00Z"}`, http.StatusCreated, "/api/cats/999"},
+		{"create bad json", "POST", "/api/cats", `{"cat_name":`, http.StatusBadRequest, ""},
+		{"create unknown field", "POST", "/api/cats", `{"name":"Tom"}`, http.StatusBadRequest, ""},
+		{"create underweight", "POST", "/api/cats", `{"cat_name":"Tom","breed_id":1,"weight":2,"date_of_birth":"2023-06-01T00:00:00Z"}`, http.StatusUnprocessableEntity, ""},
+		{"create too large", "POST", "/api/cats", `{"description":"` + strings.Repeat("x", 2<<20) + `"}`, http.StatusBadRequest, ""},
+		{"replace", "PUT", "/api/cats/1", `{"cat_name":"Whiskers","breed_id":1,"breeder_id":1,"weight":11,"date_of_birth":"2021-05-10T00:00:00Z"}`, http.StatusOK, ""},
+		{"replace missing", "PUT", "/api/cats/42", `{"cat_name":"Ghost"}`, http.StatusNotFound, ""},
+		{"replace invalid id", "PUT", "/api/cats/abc", `{"cat_name":"Ghost"}`, http.StatusBadRequest, ""},
+		{"patch", "PATCH", "/api/cats/2", `{"weight":9}`, http.StatusOK, ""},
+		{"patch missing", "PATCH", "/api/cats/42", `{"weight":9}`, http.StatusNotFound, ""},
+		{"delete", "DELETE", "/api/cats/1", "", http.StatusNoContent, ""},
+		{"delete missing", "DELETE", "/api/cats/42", "", http.StatusNotFound, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rr := httptest.NewRecorder()
+
+			routes.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("%s %s returned wrong status code: got %v want %v (body: %s)",
+					tt.method, tt.url, rr.Code, tt.expectedStatus, rr.Body.String())
+			}
+
+			if tt.expectedHeader != "" && rr.Header().Get("Location") != tt.expectedHeader {
+				t.Errorf("wrong Location header: got %q want %q",
+					rr.Header().Get("Location"), tt.expectedHeader)
+			}
+		})
+	}
+}
+
+func TestApplication_BreederAPI(t *testing.T) {
+	routes := asUser(user.Admin, testApp.routes())
+
+	tests := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		expectedStatus int
+		expectedHeader string
+	}{
+		{"get", "GET", "/api/breeders/1", "", http.StatusOK, ""},
+		{"get missing", "GET", "/api/breeders/42", "", http.StatusNotFound, ""},
+		{"get invalid id", "GET", "/api/breeders/abc", "", http.StatusBadRequest, ""},
+		{"create", "POST", "/api/breeders", `{"breeder_name":"Paws & Claws","city":"Boise","email":"hi@pawsclaws.com","active":1}`, http.StatusCreated, "/api/breeders/999"},
+		{"create bad json", "POST", "/api/breeders", `{"breeder_name":`, http.StatusBadRequest, ""},
+		{"create missing name", "POST", "/api/breeders", `{"city":"Boise"}`, http.StatusUnprocessableEntity, ""},
+		{"create bad email", "POST", "/api/breeders", `{"breeder_name":"Paws","email":"not-an-email"}`, http.StatusUnprocessableEntity, ""},
+		{"create bad phone", "POST", "/api/breeders", `{"breeder_name":"Paws","phone":"call me"}`, http.StatusUnprocessableEntity, ""},
+		{"replace", "PUT", "/api/breeders/1", `{"breeder_name":"Happy Paws Breeders","city":"Salem","active":1}`, http.StatusOK, ""},
+		{"replace missing", "PUT", "/api/breeders/42", `{"breeder_name":"Ghost"}`, http.StatusNotFound, ""},
+		{"patch", "PATCH", "/api/breeders/2", `{"active":0}`, http.StatusOK, ""},
+		{"patch invalid", "PATCH", "/api/breeders/2", `{"active":7}`, http.StatusUnprocessableEntity, ""},
+		{"patch missing", "PATCH", "/api/breeders/42", `{"active":0}`, http.StatusNotFound, ""},
+		{"delete", "DELETE", "/api/breeders/1", "", http.StatusNoContent, ""},
+		{"delete missing", "DELETE", "/api/breeders/42", "", http.StatusNotFound, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rr := httptest.NewRecorder()
+
+			routes.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("%s %s returned wrong status code: got %v want %v (body: %s)",
+					tt.method, tt.url, rr.Code, tt.expectedStatus, rr.Body.String())
+			}
+
+			if tt.expectedHeader != "" && rr.Header().Get("Location") != tt.expectedHeader {
+				t.Errorf("wrong Location header: got %q want %q",
+					rr.Header().Get("Location"), tt.expectedHeader)
+			}
+		})
+	}
+}
+
+func TestApplication_GetByIDJSON(t *testing.T) {
+	routes := testApp.routes()
+
+	tests := []struct {
+		name           string
+		url            string
+		expectedStatus int
+	}{
+		{"dog breed", "/api/dog-breeds/2", http.StatusOK},
+		{"dog breed missing", "/api/dog-breeds/42", http.StatusNotFound},
+		{"dog breed invalid id", "/api/dog-breeds/abc", http.StatusBadRequest},
+		{"cat breed", "/api/cat-breeds/1", http.StatusOK},
+		{"cat breed missing", "/api/cat-breeds/42", http.StatusNotFound},
+		{"dog", "/api/dogs/1", http.StatusOK},
+		{"dog missing", "/api/dogs/42", http.StatusNotFound},
+		{"dog negative id", "/api/dogs/-1", http.StatusBadRequest},
+		{"cat", "/api/cats/2", http.StatusOK},
+		{"cat missing", "/api/cats/42", http.StatusNotFound},
+		{"breeder", "/api/breeders/2", http.StatusOK},
+		{"breeder missing", "/api/breeders/42", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.url, nil)
+			rr := httptest.NewRecorder()
+
+			routes.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("GET %s returned wrong status code: got %v want %v (body: %s)",
+					tt.url, rr.Code, tt.expectedStatus, rr.Body.String())
+			}
+
+			if contentType := rr.Header().Get("Content-Type"); contentType != "application/json" {
+				t.Errorf("GET %s returned wrong content type: got %v want %v",
+					tt.url, contentType, "application/json")
+			}
+		})
+	}
+}
+
+func TestApplication_ListPagination(t *testing.T) {
+	routes := testApp.routes()
+
+	tests := []struct {
+		name           string
+		url            string
+		expectedStatus int
+		expectedIDs    []int
+		expectedTotal  int
+	}{
+		{"default order", "/api/dog-breeds", http.StatusOK, []int{1, 2, 3}, 3},
+		{"paged", "/api/dog-breeds?per_page=2&page=2", http.StatusOK, []int{3}, 3},
+		{"sorted desc", "/api/dog-breeds?sort=-average_weight", http.StatusOK, []int{2, 3, 1}, 3},
+		{"filter origin", "/api/dog-breeds?origin=germany", http.StatusOK, []int{2}, 1},
+		{"filter weight range", "/api/dog-breeds?min_weight=20&max_weight=60", http.StatusOK, []int{2, 3}, 2},
+		{"filter lifespan", "/api/cat-breeds?min_lifespan=15", http.StatusOK, []int{1, 2}, 2},
+		{"dogs by breeder", "/api/dogs?breeder_id=1&sort=weight", http.StatusOK, []int{2, 1}, 2},
+		{"cats sorted by name", "/api/cats?sort=-cat_name", http.StatusOK, []int{1, 2}, 2},
+		{"breeders by state", "/api/breeders?prov_state=WA", http.StatusOK, []int{2}, 1},
+		{"page past end", "/api/breeders?page=5", http.StatusOK, []int{}, 2},
+		{"bad sort field", "/api/dogs?sort=password", http.StatusBadRequest, nil, 0},
+		{"bad page", "/api/dogs?page=zero", http.StatusBadRequest, nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.url, nil)
+			rr := httptest.NewRecorder()
+
+			routes.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("GET %s returned wrong status code: got %v want %v (body: %s)",
+					tt.url, rr.Code, tt.expectedStatus, rr.Body.String())
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var page list.Page[struct {
+				ID int `json:"id"`
+			}]
+			if err := json.NewDecoder(rr.Body).Decode(&page); err != nil {
+				t.Fatal(err)
+			}
+
+			var ids []int
+			for _, item := range page.Data {
+				ids = append(ids, item.ID)
+			}
+			if fmt.Sprint(ids) != fmt.Sprint(tt.expectedIDs) {
+				t.Errorf("GET %s returned wrong ids: got %v want %v", tt.url, ids, tt.expectedIDs)
+			}
+			if page.Meta.Total != tt.expectedTotal {
+				t.Errorf("GET %s returned wrong total: got %v want %v", tt.url, page.Meta.Total, tt.expectedTotal)
+			}
+		})
+	}
+}
+
+func TestApplication_SearchBreedsJSON(t *testing.T) {
+	routes := testApp.routes()
+
+	tests := []struct {
+		name            string
+		query           string
+		expectedStatus  int
+		expectedSpecies string
+		expectedBreed   string
+		expectedMatch   string
+	}{
+		{"exact name", "chihuahua", http.StatusOK, "dog", "Chihuahua", "name"},
+		{"alternate name", "Alsatian", http.StatusOK, "dog", "German Shepherd", "alternate_name"},
+		{"alternate name typo", "alsation", http.StatusOK, "dog", "German Shepherd", "alternate_name"},
+		{"name typo", "shepard", http.StatusOK, "dog", "German Shepherd", "name"},
+		{"prefix", "lab", http.StatusOK, "dog", "Labrador Retriever", "alternate_name"},
+		{"cat typo", "siamse", http.StatusOK, "cat", "Siamese", "name"},
+		{"details", "gentle", http.StatusOK, "cat", "Persian", "details"},
+		{"too short", "a", http.StatusBadRequest, "", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/breeds/search?q="+url.QueryEscape(tt.query), nil)
+			rr := httptest.NewRecorder()
+
+			routes.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("search %q returned wrong status code: got %v want %v (body: %s)",
+					tt.query, rr.Code, tt.expectedStatus, rr.Body.String())
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				Data []search.Result `json:"data"`
+			}
+			if err := json.NewDecoder(rr.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if len(body.Data) == 0 {
+				t.Fatalf("search %q returned no results", tt.query)
+			}
+
+			top := body.Data[0]
+			if top.Species != tt.expectedSpecies || top.Breed != tt.expectedBreed || top.MatchedOn != tt.expectedMatch {
+				t.Errorf("search %q returned wrong top hit: got %s %q on %s, want %s %q on %s",
+					tt.query, top.Species, top.Breed, top.MatchedOn,
+					tt.expectedSpecies, tt.expectedBreed, tt.expectedMatch)
+			}
+		})
+	}
+}
+
+func TestApplication_ExpandRelations(t *testing.T) {
+	routes := testApp.routes()
+
+	tests := []struct {
+		name            string
+		url             string
+		expectedStatus  int
+		expectedBreed   string
+		expectedBreeder string
+	}{
+		{"lean dog", "/api/dogs/1", http.StatusOK, "", ""},
+		{"dog with breed", "/api/dogs/1?expand=breed", http.StatusOK, "German Shepherd", ""},
+		{"dog with both", "/api/dogs/1?expand=breed,breeder", http.StatusOK, "German Shepherd", "Happy Paws Breeders"},
+		{"cat with breeder", "/api/cats/2?expand=breeder", http.StatusOK, "", "Happy Paws Breeders"},
+		{"unknown expansion", "/api/dogs/1?expand=owner", http.StatusBadRequest, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.url, nil)
+			rr := httptest.NewRecorder()
+
+			routes.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Fatalf("GET %s returned wrong status code: got %v want %v (body: %s)",
+					tt.url, rr.Code, tt.expectedStatus, rr.Body.String())
+			}
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			var body struct {
+				Breed *struct {
+					Breed string `json:"breed"`
+				} `json:"breed"`
+				Breeder *struct {
+					BreederName string `json:"breeder_name"`
+				} `json:"breeder"`
+			}
+			if err := json.NewDecoder(rr.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+
+			var breed, breederName string
+			if body.Breed != nil {
+				breed = body.Breed.Breed
+			}
+			if body.Breeder != nil {
+				breederName = body.Breeder.BreederName
+			}
+			if breed != tt.expectedBreed || breederName != tt.expectedBreeder {
+				t.Errorf("GET %s embedded wrong relations: got breed %q breeder %q, want %q %q",
+					tt.url, breed, breederName, tt.expectedBreed, tt.expectedBreeder)
+			}
+		})
+	}
+
+	// list endpoints expand every item
+	req := httptest.NewRequest("GET", "/api/dogs?expand=breed", nil)
+	rr := httptest.NewRecorder()
+	routes.ServeHTTP(rr, req)
+
+	var page list.Page[struct {
+		Breed *struct {
+			ID int `json:"id"`
+		} `json:"breed"`
+	}]
+	if err := json.NewDecoder(rr.Body).Decode(&page); err != nil {
+		t.Fatal(err)
+	}
+	for i, item := range page.Data {
+		if item.Breed == nil {
+			t.Errorf("dog %d in expanded list has no breed", i)
+		}
+	}
+}
+
+func TestApplication_MemoryCreateThenRead(t *testing.T) {
+	app := newMemoryApp()
+	routes := asUser(user.Admin, app.routes())
+
+	steps := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{"create", "POST", "/api/dogs", `{"dog_name":"Rex","breed_id":2,"breeder_id":1,"weight":70,"date_of_birth":"2022-04-01T00:00:00Z"}`, http.StatusCreated, `"id":3`},
+		{"read created", "GET", "/api/dogs/3?expand=breed,breeder", "", http.StatusOK, `"breeder_name":"Happy Paws Breeders"`},
+		{"listed", "GET", "/api/dogs?sort=-id", "", http.StatusOK, `"total":3`},
+		{"patch", "PATCH", "/api/dogs/3", `{"dog_name":"Rexy"}`, http.StatusOK, `"dog_name":"Rexy"`},
+		{"read patched", "GET", "/api/dogs/3", "", http.StatusOK, `"dog_name":"Rexy"`},
+		{"delete", "DELETE", "/api/dogs/3", "", http.StatusNoContent, ""},
+		{"read deleted", "GET", "/api/dogs/3", "", http.StatusNotFound, ""},
+		{"create breeder", "POST", "/api/breeders", `{"breeder_name":"Cat Haven","email":"hi@cathaven.com","phone":"555-9999","active":1}`, http.StatusCreated, `"id":3`},
+		{"create cat for new breeder", "POST", "/api/cats", `{"cat_name":"Tom","breed_id":1,"breeder_id":3,"weight":10,"date_of_birth":"2022-04-01T00:00:00Z"}`, http.StatusCreated, `"breeder_id":3`},
+	}
+
+	// steps depend on each other, so stop at the first failure
+	for _, st := range steps {
+		req := httptest.NewRequest(st.method, st.url, strings.NewReader(st.body))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+
+		routes.ServeHTTP(rr, req)
+
+		if rr.Code != st.expectedStatus {
+			t.Fatalf("%s: %s %s returned wrong status code: got %v want %v (body: %s)",
+				st.name, st.method, st.url, rr.Code, st.expectedStatus, rr.Body.String())
+		}
+		if !strings.Contains(rr.Body.String(), st.expectedBody) {
+			t.Fatalf("%s: expected body to contain %s, got %s", st.name, st.expectedBody, rr.Body.String())
+		}
+	}
+}
+
+func TestApplication_Onboarding(t *testing.T) {
+	app := newMemoryApp()
+	routes := asUser(user.Admin, app.routes())
+
+	breeder := `"breeder":{"breeder_name":"Pack Leaders","email":"hello@packleaders.com","phone":"555-2468","active":1}`
+	rex := `{"dog_name":"Rex","breed_id":2,"weight":70,"date_of_birth":"2022-04-01T00:00:00Z"}`
+	tom := `{"cat_name":"Tom","breed_id":1,"weight":10,"date_of_birth":"2022-04-01T00:00:00Z"}`
+	heavy := `{"dog_name":"Tank","breed_id":2,"weight":500,"date_of_birth":"2022-04-01T00:00:00Z"}`
+
+	steps := []struct {
+		name             string
+		method           string
+		url              string
+		body             string
+		expectedStatus   int
+		expectedBody     string
+		expectedLocation string
+	}{
+		{"invalid dog rolls back", "POST", "/api/onboarding", `{` + breeder + `,"dogs":[` + rex + `,` + heavy + `],"cats":[` + tom + `]}`,
+			http.StatusUnprocessableEntity, `"field":"dogs[1].weight"`, ""},
+		{"breeder not kept", "GET", "/api/breeders", "", http.StatusOK, `"total":2`, ""},
+		{"dogs not kept", "GET", "/api/dogs", "", http.StatusOK, `"total":2`, ""},
+		{"missing breeder", "POST", "/api/onboarding", `{"dogs":[` + rex + `]}`, http.StatusUnprocessableEntity, `"field":"breeder"`, ""},
+		{"onboard", "POST", "/api/onboarding", `{` + breeder + `,"dogs":[` + rex + `],"cats":[` + tom + `]}`,
+			http.StatusCreated, `"breeder_id":3`, "/api/breeders/3"},
+		{"dog belongs to new breeder", "GET", "/api/dogs/3?expand=breeder", "", http.StatusOK, `"breeder_name":"Pack Leaders"`, ""},
+		{"cat belongs to new breeder", "GET", "/api/cats/3", "", http.StatusOK, `"breeder_id":3`, ""},
+	}
+
+	// steps depend on each other, so stop at the first failure
+	for _, st := range steps {
+		req := httptest.NewRequest(st.method, st.url, strings.NewReader(st.body))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+
+		routes.ServeHTTP(rr, req)
+
+		if rr.Code != st.expectedStatus {
+			t.Fatalf("%s: %s %s returned wrong status code: got %v want %v (body: %s)",
+				st.name, st.method, st.url, rr.Code, st.expectedStatus, rr.Body.String())
+		}
+		if !strings.Contains(rr.Body.String(), st.expectedBody) {
+			t.Fatalf("%s: expected body to contain %s, got %s", st.name, st.expectedBody, rr.Body.String())
+		}
+		if location := rr.Header().Get("Location"); location != st.expectedLocation {
+			t.Fatalf("%s: wrong Location: got %q want %q", st.name, location, st.expectedLocation)
+		}
+	}
+}
+
+func TestApplication_DBStats(t *testing.T) {
+	pool := database.Pool{MaxOpen: 7, MaxIdle: 3, MaxLifetime: time.Minute, PingAttempts: 1}
+	db, err := database.Open(context.Background(), database.SQLite, ":memory:", pool)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	withDB := newMemoryApp()
+	withDB.db = db
+	withDB.config.dbDriver, withDB.config.pool = database.SQLite, pool
+
+	memory := newMemoryApp()
+	memory.config.dbDriver = memoryDriver
+
+	tests := []struct {
+		name           string
+		app            application
+		expectedStatus int
+		expectedBody   []string
+	}{
+		{"database", withDB, http.StatusOK, []string{`"driver":"sqlite"`, `"max_open_connections":7`, `"max_idle_connections":3`, `"max_lifetime":"1m0s"`}},
+		{"memory backend", memory, http.StatusNotFound, []string{`"code":"not_found"`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/debug/db", nil)
+			rr := httptest.NewRecorder()
+
+			asUser(user.Admin, tt.app.routes()).ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("wrong status: got %v want %v (body: %s)", rr.Code, tt.expectedStatus, rr.Body.String())
+			}
+			for _, want := range tt.expectedBody {
+				if !strings.Contains(rr.Body.String(), want) {
+					t.Errorf("expected body to contain %s, got %s", want, rr.Body.String())
+				}
+			}
+		})
+	}
+}
+
+func TestApplication_BreedCache(t *testing.T) {
+	cached := dog.NewCachedRepository(dog.NewMockRepository(), time.Minute, 10)
+	app := testApp
+	app.dogBreedCache = cached
+	app.DogHandler = dog.NewHandler(dog.NewService(cached, breeder.NewMockRepository()))
+	routes := asUser(user.Admin, app.routes())
+
+	get := func(url string, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", url, nil)
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		rr := httptest.NewRecorder()
+		routes.ServeHTTP(rr, req)
+		return rr
+	}
+
+	first := get("/api/dog-breeds", nil)
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" || first.Header().Get("Last-Modified") != "" {
+		t.Fatalf("expected 200 with an ETag and no Last-Modified, got %v %q %q", first.Code, etag, first.Header().Get("Last-Modified"))
+	}
+
+	tests := []struct {
+		name           string
+		headers        map[string]string
+		expectedStatus int
+	}{
+		{"matching etag", map[string]string{"If-None-Match": etag}, http.StatusNotModified},
+		{"weak etag in a list", map[string]string{"If-None-Match": `"other", W/` + etag}, http.StatusNotModified},
+		{"stale etag", map[string]string{"If-None-Match": `"other"`}, http.StatusOK},
+		{"dates are not trusted", map[string]string{"If-Modified-Since": time.Now().UTC().Format(http.TimeFormat)}, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := get("/api/dog-breeds", tt.headers)
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("wrong status: got %v want %v", rr.Code, tt.expectedStatus)
+			}
+			if tt.expectedStatus == http.StatusNotModified && rr.Body.Len() != 0 {
+				t.Errorf("expected an empty 304 body, got %s", rr.Body.String())
+			}
+		})
+	}
+
+	// one miss for the first request, then every conditional request is a hit
+	stats := get("/debug/cache", nil).Body.String()
+	if !strings.Contains(stats, `"dog_breeds":{"hits":4,"misses":1`) {
+		t.Errorf("unexpected cache stats %s", stats)
+	}
+}
+
+func TestApplication_Pages(t *testing.T) {
+	routes := testApp.routes()
+
+	// concurrent renders share the preloaded templates
+	var wg sync.WaitGroup
+	for _, url := range []string{"/", "/about", "/dog-breeds", "/cat-breeds", "/test-patterns", "/", "/about"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := httptest.NewRequest("GET", url, nil)
+			rr := httptest.NewRecorder()
+
+			routes.ServeHTTP(rr, req)
+
+			if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), "<html") {
+				t.Errorf("%s: expected a rendered page, got %v %.80q", url, rr.Code, rr.Body.String())
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestApplication_ShowPage(t *testing.T) {
+	routes := testApp.routes()
+
+	tests := []struct {
+		name           string
+		url            string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{"home", "/", http.StatusOK, "Go find a pet!"},
+		{"registered page", "/about", http.StatusOK, "<h1>About</h1>"},
+		{"unknown page", "/no-such-page", http.StatusNotFound, "Page not found"},
+		{"template name", "/about.page.gohtml", http.StatusNotFound, "Page not found"},
+		{"error page by name", "/not-found", http.StatusNotFound, "Page not found"},
+		{"layout", "/base", http.StatusNotFound, "Page not found"},
+		{"wrong case", "/About", http.StatusNotFound, "Page not found"},
+		{"encoded traversal", "/..%2Ftemplates%2Fbase.layout", http.StatusNotFound, "Page not found"},
+		{"nested path", "/about/team", http.StatusNotFound, "Page not found"},
+		{"unknown api route", "/api/no-such-endpoint", http.StatusNotFound, `"code":"not_found"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.url, nil)
+			rr := httptest.NewRecorder()
+
+			routes.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("wrong status: got %v want %v", rr.Code, tt.expectedStatus)
+			}
+			if !strings.Contains(rr.Body.String(), tt.expectedBody) {
+				t.Errorf("expected body to contain %q, got %.200q", tt.expectedBody, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestApplication_ServerErrorPage(t *testing.T) {
+	fsys := fstest.MapFS{}
+	for _, name := range []string{"base.layout.gohtml", "partials/header.partial.gohtml", "partials/footer.partial.gohtml", serverErrorPage} {
+		data, err := fs.ReadFile(templates.FS, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fsys[name] = &fstest.MapFile{Data: data}
+	}
+	// parses, but fails half way through execution
+	fsys["about.page.gohtml"] = &fstest.MapFile{Data: []byte(`{{template "base" .}}{{define "content"}}partial output {{.Missing}}{{end}}`)}
+
+	pages, err := view.New(fsys, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	app := testApp
+	app.templates = pages
+
+	req := httptest.NewRequest("GET", "/about", nil)
+	rr := httptest.NewRecorder()
+	app.routes().ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusInternalServerError {
+		t.Errorf("wrong status: got %v want %v", rr.Code, http.StatusInternalServerError)
+	}
+	if body := rr.Body.String(); !strings.Contains(body, "Something went wrong") || strings.Contains(body, "partial output") {
+		t.Errorf("expected only the 500 page, got %.200q", body)
+	}
+}
+
+func TestApplication_LoadTemplates(t *testing.T) {
+	// a copy of the templates directory to run from
+	dir := t.TempDir()
+	if err := os.CopyFS(filepath.Join(dir, "templates"), templates.FS); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+	edit := func() {
+		t.Helper()
+		page := `{{template "base" .}}{{define "content"}}edited on disk{{end}}`
+		if err := os.WriteFile(filepath.Join(dir, "templates", "about.page.gohtml"), []byte(page), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	about := func(app *application) string {
+		rr := httptest.NewRecorder()
+		app.render(rr, httptest.NewRequest("GET", "/about", nil), "about.page.gohtml", nil)
+		return rr.Body.String()
+	}
+
+	// without -cache pages come from ./templates, so edits show up at once
+	var dev application
+	if err := dev.loadTemplates(); err != nil {
+		t.Fatal(err)
+	}
+	edit()
+	if body := about(&dev); !strings.Contains(body, "edited on disk") {
+		t.Errorf("expected the edited page without -cache, got %.200s", body)
+	}
+
+	// with -cache the embedded copy is preloaded and the directory ignored
+	prod := application{config: appConfig{useCache: true}}
+	if err := prod.loadTemplates(); err != nil {
+		t.Fatal(err)
+	}
+	if body := about(&prod); strings.Contains(body, "edited on disk") {
+		t.Errorf("expected the embedded page with -cache, got %.200s", body)
+	}
+}
+
+func TestApplication_Login(t *testing.T) {
+	app := newMemoryApp()
+	routes := app.routes()
+
+	form := func(email, password, next string) string {
+		return url.Values{"email": {email}, "password": {password}, "next": {next}}.Encode()
+	}
+
+	steps := []struct {
+		name             string
+		method           string
+		url              string
+		body             string
+		expectedStatus   int
+		expectedBody     string
+		expectedLocation string
+	}{
+		{"anonymous", "GET", "/api/me", "", http.StatusUnauthorized, `"code":"unauthorized"`, ""},
+		{"login form", "GET", "/login?next=/about", "", http.StatusOK, `value="/about"`, ""},
+		{"wrong password", "POST", "/login", form("admin@example.com", "wrong", "/about"), http.StatusUnauthorized, "Invalid email or password.", ""},
+		{"unknown email", "POST", "/login", form("nobody@example.com", "verysecret", ""), http.StatusUnauthorized, "Invalid email or password.", ""},
+		{"offsite next", "POST", "/login", form("admin@example.com", "verysecret", "//evil.example.com"), http.StatusSeeOther, "", "/"},
+		{"home", "GET", "/", "", http.StatusOK, "Admin User", ""},
+		{"offsite next with a tab", "POST", "/login", form("admin@example.com", "verysecret", "/\t/evil.example.com"), http.StatusSeeOther, "", "/"},
+		{"home again", "GET", "/", "", http.StatusOK, "Admin User", ""},
+		{"login", "POST", "/login", form("admin@example.com", "verysecret", "/about"), http.StatusSeeOther, "", "/about"},
+		{"me", "GET", "/api/me", "", http.StatusOK, `"email":"admin@example.com"`, ""},
+		{"password not sent", "GET", "/api/me", "", http.StatusOK, `"access_level":30}`, ""},
+		{"nav shows user", "GET", "/about", "", http.StatusOK, "Admin User", ""},
+		{"logout page", "GET", "/logout", "", http.StatusOK, "You are logged in as admin@example.com", ""},
+		{"logout", "POST", "/logout", "", http.StatusSeeOther, "", "/login"},
+		{"logged out", "GET", "/api/me", "", http.StatusUnauthorized, `"code":"unauthorized"`, ""},
+		{"api login", "POST", "/api/login", `{"email":"admin@example.com","password":"verysecret"}`, http.StatusOK, `"first_name":"Admin"`, ""},
+		{"api me", "GET", "/api/me", "", http.StatusOK, `"email":"admin@example.com"`, ""},
+		{"api logout", "POST", "/api/logout", "", http.StatusNoContent, "", ""},
+		{"api logged out", "GET", "/api/me", "", http.StatusUnauthorized, `"code":"unauthorized"`, ""},
+	}
+
+	// steps share a browser, so cookies carry over and the first failure stops
+	// the test. Cleared cookies are kept, so the steps after a logout replay
+	// the old session token.
+	b := newBrowser(routes)
+	for _, st := range steps {
+		req := httptest.NewRequest(st.method, st.url, strings.NewReader(st.body))
+		if strings.HasPrefix(st.url, "/api/") {
+			req.Header.Set("Content-Type", "application/json")
+		} else {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+
+		rr := b.do(req)
+
+		if rr.Code != st.expectedStatus {
+			t.Fatalf("%s: %s %s returned wrong status code: got %v want %v (body: %.200s)",
+				st.name, st.method, st.url, rr.Code, st.expectedStatus, rr.Body.String())
+		}
+		if !strings.Contains(rr.Body.String(), st.expectedBody) {
+			t.Fatalf("%s: expected body to contain %s, got %.200s", st.name, st.expectedBody, rr.Body.String())
+		}
+		if location := rr.Header().Get("Location"); location != st.expectedLocation {
+			t.Fatalf("%s: wrong Location: got %q want %q", st.name, location, st.expectedLocation)
+		}
+		for _, c := range rr.Result().Cookies() {
+			if !c.HttpOnly || c.SameSite != http.SameSiteLaxMode {
+				t.Fatalf("%s: cookie %s is not HttpOnly and SameSite=Lax", st.name, c.Name)
+			}
+		}
+	}
+}
+
+func TestLocalPath(t *testing.T) {
+	tests := []struct {
+		next     string
+		expected string
+	}{
+		{"/about", "/about"},
+		{"/dogs?page=2#top", "/dogs?page=2#top"},
+		{"", "/"},
+		{"about", "/"},
+		{"//evil.example.com", "/"},
+		{"///evil.example.com", "/"},
+		{"https://evil.example.com/", "/"},
+		{"javascript:alert(1)", "/"},
+		{`/\evil.example.com`, "/"},
+		{`\\evil.example.com`, "/"},
+		{"/\t/evil.example.com", "/"},
+		{"/\r\n/evil.example.com", "/"},
+		{"\n//evil.example.com", "/"},
+		{"/ /evil.example.com", "/"},
+		{"/%2F/evil.example.com", "/"},
+		{"/%09/evil.example.com", "/"},
+	}
+
+	for _, tt := range tests {
+		if got := localPath(tt.next); got != tt.expected {
+			t.Errorf("localPath(%q) = %q, want %q", tt.next, got, tt.expected)
+		}
+	}
+}
+
+func TestApplication_Authorization(t *testing.T) {
+	app := newMemoryApp()
+	routes := app.routes()
+
+	// public routes need no role, and anonymous visitors hold none
+	const public user.Role = 0
+	names := map[user.Role]string{public: "anonymous", user.Viewer: "viewer", user.Staff: "staff", user.Admin: "admin"}
+
+	tests := []struct {
+		method string
+		url    string
+		role   user.Role
+	}{
+		{"GET", "/", public},
+		{"GET", "/about", public},
+		{"GET", "/login", public},
+		{"GET", "/api/dogs", public},
+		{"GET", "/api/dogs/1", public},
+		{"GET", "/api/dog-breeds", public},
+		{"GET", "/api/cats/1", public},
+		{"GET", "/api/breeders", public},
+		{"GET", "/api/breeds/search?q=lab", public},
+		{"GET", "/api/me", user.Viewer},
+		{"GET", "/api/tokens", user.Viewer},
+		{"POST", "/api/tokens", user.Viewer},
+		{"DELETE", "/api/tokens/1", user.Viewer},
+		{"POST", "/api/dogs", user.Staff},
+		{"PUT", "/api/dogs/1", user.Staff},
+		{"PATCH", "/api/dogs/1", user.Staff},
+		{"DELETE", "/api/dogs/1", user.Staff},
+		{"POST", "/api/cats", user.Staff},
+		{"PUT", "/api/cats/1", user.Staff},
+		{"PATCH", "/api/cats/1", user.Staff},
+		{"DELETE", "/api/cats/1", user.Staff},
+		{"POST", "/api/breeders", user.Staff},
+		{"PUT", "/api/breeders/1", user.Staff},
+		{"PATCH", "/api/breeders/1", user.Staff},
+		{"DELETE", "/api/breeders/1", user.Staff},
+		{"POST", "/api/onboarding", user.Staff},
+		{"GET", "/debug/db", user.Admin},
+		{"GET", "/debug/cache", user.Admin},
+	}
+
+	for _, tt := range tests {
+		for _, role := range []user.Role{public, user.Viewer, user.Staff, user.Admin} {
+			t.Run(tt.method+" "+tt.url+" as "+names[role], func(t *testing.T) {
+				req := httptest.NewRequest(tt.method, tt.url, strings.NewReader(`{}`))
+				req.Header.Set("Content-Type", "application/json")
+				rr := httptest.NewRecorder()
+
+				if role == public {
+					routes.ServeHTTP(rr, req)
+				} else {
+					asUser(role, routes).ServeHTTP(rr, req)
+				}
+
+				api := strings.HasPrefix(tt.url, "/api/")
+				switch {
+				case role >= tt.role:
+					if rr.Code == http.StatusUnauthorized || rr.Code == http.StatusForbidden || rr.Code == http.StatusSeeOther {
+						t.Errorf("expected access, got %v: %.200s", rr.Code, rr.Body.String())
+					}
+				case role == public && api:
+					if rr.Code != http.StatusUnauthorized || !strings.Contains(rr.Body.String(), `"code":"unauthorized"`) {
+						t.Errorf("expected a 401 JSON error, got %v: %.200s", rr.Code, rr.Body.String())
+					}
+				case role == public:
+					expected := "/login?next=" + url.QueryEscape(tt.url)
+					if rr.Code != http.StatusSeeOther || rr.Header().Get("Location") != expected {
+						t.Errorf("expected a redirect to %s, got %v %q", expected, rr.Code, rr.Header().Get("Location"))
+					}
+				case api:
+					if rr.Code != http.StatusForbidden || !strings.Contains(rr.Body.String(), `"code":"forbidden"`) {
+						t.Errorf("expected a 403 JSON error, got %v: %.200s", rr.Code, rr.Body.String())
+					}
+				default:
+					if rr.Code != http.StatusForbidden || !strings.Contains(rr.Body.String(), "Access denied") {
+						t.Errorf("expected the 403 page, got %v: %.200s", rr.Code, rr.Body.String())
+					}
+				}
+			})
+		}
+	}
+
+	// every write route outside the public account forms and static files must be in the table above
+	covered := map[string]bool{
+		"POST /login": true, "POST /logout": true, "POST /register": true,
+		"POST /forgot-password": true, "POST /reset-password": true,
+		"POST /api/login": true, "POST /api/logout": true,
+	}
+	for _, tt := range tests {
+		covered[tt.method+" "+tt.url] = true
+	}
+	err := chi.Walk(routes.(chi.Routes), func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if method == "GET" || route == "/static/*" {
+			return nil
+		}
+		if !covered[method+" "+strings.Replace(route, "{id}", "1", 1)] {
+			t.Errorf("%s %s has no authorization test", method, route)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestApplication_APITokens(t *testing.T) {
+	app := newMemoryApp()
+	routes := app.routes()
+
+	// log in through the form to get a session cookie, then pick up the
+	// CSRF token that goes with it
+	b := newBrowser(routes)
+	b.do(httptest.NewRequest("GET", "/login", nil))
+	req := httptest.NewRequest("POST", "/login", strings.NewReader("email=admin@example.com&password=verysecret"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if rr := b.do(req); rr.Code != http.StatusSeeOther || b.cookies[user.CookieName] == nil {
+		t.Fatalf("login failed: %v", rr.Code)
+	}
+	b.do(httptest.NewRequest("GET", "/", nil))
+
+	do := func(method, url, body string, auth func(*http.Request)) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(method, url, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		auth(req)
+		rr := httptest.NewRecorder()
+		routes.ServeHTTP(rr, req)
+		return rr
+	}
+	withSession := func(req *http.Request) {
+		req.AddCookie(b.cookies[user.CookieName])
+		req.Header.Set(csrfHeader, b.csrf)
+	}
+	bearer := func(token string) func(*http.Request) {
+		return func(req *http.Request) { req.Header.Set("Authorization", "Bearer "+token) }
+	}
+	issue := func(body string) string {
+		t.Helper()
+		rr := do("POST", "/api/tokens", body, withSession)
+		var issued struct {
+			Token string `json:"token"`
+		}
+		if rr.Code != http.StatusCreated || json.Unmarshal(rr.Body.Bytes(), &issued) != nil {
+			t.Fatalf("creating a token returned %v: %s", rr.Code, rr.Body.String())
+		}
+		return issued.Token
+	}
+
+	readToken := issue(`{"name":"reports","scopes":["read"]}`)
+	writeToken := issue(`{"name":"intake","scopes":["read","write"],"expires_at":"2999-01-01T00:00:00Z"}`)
+	if body := do("GET", "/api/tokens", "", withSession).Body.String(); strings.Contains(body, readToken) || strings.Contains(body, "token_hash") {
+		t.Fatalf("token list leaks secrets: %s", body)
+	}
+	dog := `{"dog_name":"Rex","breed_id":2,"breeder_id":1,"weight":70,"date_of_birth":"2022-04-01T00:00:00Z"}`
+
+	tests := []struct {
+		name           string
+		method         string
+		url            string
+		body           string
+		auth           func(*http.Request)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{"list", "GET", "/api/tokens", "", withSession, http.StatusOK, `"name":"intake","scopes":["read","write"],"expires_at":"2999-01-01T00:00:00Z"`},
+		{"oldest first", "GET", "/api/tokens", "", withSession, http.StatusOK, `"id":1,"name":"reports"`},
+		{"scope above role", "POST", "/api/tokens", `{"name":"x","scopes":["root"]}`, withSession, http.StatusUnprocessableEntity, `"field":"scopes"`},
+		{"read token is a viewer", "GET", "/api/me", "", bearer(readToken), http.StatusOK, `"access_level":10`},
+		{"read token cannot write", "POST", "/api/dogs", dog, bearer(readToken), http.StatusForbidden, `"code":"forbidden"`},
+		{"write token writes", "POST", "/api/dogs", dog, bearer(writeToken), http.StatusCreated, `"dog_name":"Rex"`},
+		{"write token is not admin", "GET", "/debug/cache", "", bearer(writeToken), http.StatusForbidden, ""},
+		{"tokens cannot mint tokens", "POST", "/api/tokens", `{"name":"x","scopes":["read"]}`, bearer(writeToken), http.StatusForbidden, "cannot manage"},
+		{"last use recorded", "GET", "/api/tokens", "", withSession, http.StatusOK, `"last_used_at"`},
+		{"unknown token", "GET", "/api/dogs", "", bearer(user.TokenPrefix + "nope"), http.StatusUnauthorized, "invalid API token"},
+		{"wrong scheme", "GET", "/api/dogs", "", func(req *http.Request) { req.SetBasicAuth("admin", "x") }, http.StatusUnauthorized, "Bearer"},
+		{"revoke other id", "DELETE", "/api/tokens/99", "", withSession, http.StatusNotFound, ""},
+		{"revoke", "DELETE", "/api/tokens/2", "", withSession, http.StatusNoContent, ""},
+		{"revoked token", "GET", "/api/me", "", bearer(writeToken), http.StatusUnauthorized, "invalid API token"},
+	}
+
+	// steps depend on each other, so stop at the first failure
+	for _, tt := range tests {
+		rr := do(tt.method, tt.url, tt.body, tt.auth)
+		if rr.Code != tt.expectedStatus {
+			t.Fatalf("%s: %s %s returned wrong status code: got %v want %v (body: %.200s)",
+				tt.name, tt.method, tt.url, rr.Code, tt.expectedStatus, rr.Body.String())
+		}
+		if !strings.Contains(rr.Body.String(), tt.expectedBody) {
+			t.Fatalf("%s: expected body to contain %s, got %.300s", tt.name, tt.expectedBody, rr.Body.String())
+		}
+	}
+}
+
+func TestApplication_AccountEmails(t *testing.T) {
+	app := newMemoryApp()
+	routes := app.routes()
+	outbox := app.mail.(*mailertest.Outbox)
+
+	// mailed returns the path and query of the link in the last email
+	mailed := func() string {
+		msg, ok := outbox.Last()
+		if !ok {
+			t.Fatal("no email sent")
+		}
+		_, link, ok := strings.Cut(msg.Body, testBaseURL)
+		if !ok {
+			t.Fatalf("no link in %q", msg.Body)
+		}
+		return strings.Fields(link)[0]
+	}
+	var link string
+	form := func(values ...string) string {
+		v := url.Values{}
+		for i := 0; i < len(values); i += 2 {
+			v.Set(values[i], values[i+1])
+		}
+		return v.Encode()
+	}
+
+	steps := []struct {
+		name           string
+		method         string
+		url            func() string
+		body           func() string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{"register form", "GET", fixed("/register"), fixed(""), http.StatusOK, "Create an account"},
+		{"invalid registration", "POST", fixed("/register"), fixed(form("first_name", "Jo", "email", "jo@example", "password", "pw")),
+			http.StatusUnprocessableEntity, "Last name is required"},
+		{"register", "POST", fixed("/register"), fixed(form("first_name", "Jo", "last_name", "Lee", "email", "jo@example.com", "password", "long enough")),
+			http.StatusOK, "We sent an email to jo@example.com"},
+		{"register again", "POST", fixed("/register"), fixed(form("first_name", "Jo", "last_name", "Lee", "email", "jo@example.com", "password", "long enough")),
+			http.StatusOK, "We sent an email to jo@example.com"},
+		{"inactive login", "POST", fixed("/login"), fixed(form("email", "jo@example.com", "password", "long enough")), http.StatusUnauthorized, "Invalid email"},
+		{"tampered activation", "GET", func() string { link = mailed(); return link + "x" }, fixed(""), http.StatusBadRequest, "Link expired"},
+		{"activate", "GET", func() string { return link }, fixed(""), http.StatusOK, "Account activated"},
+		{"active login", "POST", fixed("/login"), fixed(form("email", "jo@example.com", "password", "long enough")), http.StatusSeeOther, ""},
+		{"forgot password form", "GET", fixed("/forgot-password"), fixed(""), http.StatusOK, `name="email"`},
+		{"forgot password", "POST", fixed("/forgot-password"), fixed(form("email", "jo@example.com")), http.StatusOK, "we sent it a link"},
+		{"unknown email", "POST", fixed("/forgot-password"), fixed(form("email", "nobody@example.com")), http.StatusOK, "we sent it a link"},
+		{"reset form", "GET", func() string { link = mailed(); return link }, fixed(""), http.StatusOK, `name="token"`},
+		{"weak password", "POST", fixed("/reset-password"), func() string { return form("token", token(link), "password", "short") },
+			http.StatusUnprocessableEntity, "Password must be at least 8 characters"},
+		{"reset", "POST", fixed("/reset-password"), func() string { return form("token", token(link), "password", "a new secret") },
+			http.StatusOK, "Password changed"},
+		{"link used", "GET", func() string { return link }, fixed(""), http.StatusBadRequest, "already used"},
+		{"old password", "POST", fixed("/login"), fixed(form("email", "jo@example.com", "password", "long enough")), http.StatusUnauthorized, "Invalid email"},
+		{"new password", "POST", fixed("/login"), fixed(form("email", "jo@example.com", "password", "a new secret")), http.StatusSeeOther, ""},
+	}
+
+	// steps depend on each other, so stop at the first failure
+	b := newBrowser(routes)
+	for _, st := range steps {
+		u := st.url()
+		req := httptest.NewRequest(st.method, u, strings.NewReader(st.body()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		rr := b.do(req)
+
+		if rr.Code != st.expectedStatus {
+			t.Fatalf("%s: %s %s returned wrong status code: got %v want %v (body: %.300s)",
+				st.name, st.method, u, rr.Code, st.expectedStatus, rr.Body.String())
+		}
+		if !strings.Contains(rr.Body.String(), st.expectedBody) {
+			t.Fatalf("%s: expected body to contain %s, got %.2000s", st.name, st.expectedBody, rr.Body.String())
+		}
+	}
+	if n := len(outbox.Messages()); n != 3 {
+		t.Errorf("expected two activation emails and a reset email, got %d", n)
+	}
+}
+
+func TestApplication_CSRF(t *testing.T) {
+	app := newMemoryApp()
+	routes := app.routes()
+	b := newBrowser(routes)
+
+	// send posts body to url as a form, or as JSON for /api/ URLs, with the
+	// browser's cookies and the given CSRF token rather than its own
+	send := func(url, body, csrf string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest("POST", url, strings.NewReader(body))
+		if strings.HasPrefix(url, "/api/") {
+			req.Header.Set("Content-Type", "application/json")
+		} else {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+		req.Header.Set(csrfHeader, csrf)
+		saved := b.csrf
+		b.csrf = ""
+		defer func() { b.csrf = saved }()
+		return b.do(req)
+	}
+
+	// anonymous API calls need no token, so they get neither token nor cookie
+	rr := b.do(httptest.NewRequest("GET", "/api/dog-breeds", nil))
+	if rr.Header().Get(csrfHeader) != "" || b.cookies[csrfCookie] != nil {
+		t.Fatalf("expected no CSRF token or cookie for an anonymous API call, got %q and %+v",
+			rr.Header().Get(csrfHeader), b.cookies[csrfCookie])
+	}
+
+	// the login form carries the token that the header reports
+	rr = b.do(httptest.NewRequest("GET", "/login", nil))
+	anonymous := rr.Header().Get(csrfHeader)
+	if anonymous == "" || !strings.Contains(rr.Body.String(), `name="csrf_token" value="`+anonymous+`"`) {
+		t.Fatalf("login form has no CSRF field for %q: %s", anonymous, rr.Body.String())
+	}
+	if c := b.cookies[csrfCookie]; c == nil || !c.HttpOnly {
+		t.Fatalf("expected an HttpOnly csrf cookie, got %+v", c)
+	}
+
+	credentials := url.Values{"email": {"admin@example.com"}, "password": {"verysecret"}}
+	if rr := send("/login", credentials.Encode(), ""); rr.Code != http.StatusForbidden || !strings.Contains(rr.Body.String(), "Form expired") {
+		t.Fatalf("login without a token: expected the 403 page, got %v", rr.Code)
+	}
+	if rr := send("/login", credentials.Encode(), "forged"); rr.Code != http.StatusForbidden {
+		t.Fatalf("login with a forged token: expected 403, got %v", rr.Code)
+	}
+	credentials.Set(csrfField, anonymous)
+	if rr := send("/login", credentials.Encode(), ""); rr.Code != http.StatusSeeOther {
+		t.Fatalf("login with the form token: expected 303, got %v: %.200s", rr.Code, rr.Body.String())
+	}
+
+	// logging in starts a new token, so one learned before cannot be reused
+	session := b.do(httptest.NewRequest("GET", "/api/me", nil)).Header().Get(csrfHeader)
+	if session == "" || session == anonymous {
+		t.Fatalf("expected a new token after login, got %q", session)
+	}
+
+	tests := []struct {
+		name           string
+		url            string
+		body           string
+		csrf           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{"api without token", "/api/tokens", `{"name":"x","scopes":["read"]}`, "", http.StatusForbidden, "CSRF"},
+		{"api with old token", "/api/tokens", `{"name":"x","scopes":["read"]}`, anonymous, http.StatusForbidden, "CSRF"},
+		{"api with token", "/api/tokens", `{"name":"x","scopes":["read"]}`, session, http.StatusCreated, `"name":"x"`},
+		{"logout without token", "/logout", "", "", http.StatusForbidden, "Form expired"},
+		{"still logged in", "/api/tokens", `{"name":"y","scopes":["read"]}`, session, http.StatusCreated, `"name":"y"`},
+	}
+
+	// steps depend on each other, so stop at the first failure
+	for _, tt := range tests {
+		rr := send(tt.url, tt.body, tt.csrf)
+		if rr.Code != tt.expectedStatus {
+			t.Fatalf("%s: POST %s returned wrong status code: got %v want %v (body: %.200s)",
+				tt.name, tt.url, rr.Code, tt.expectedStatus, rr.Body.String())
+		}
+		if !strings.Contains(rr.Body.String(), tt.expectedBody) {
+			t.Fatalf("%s: expected body to contain %s, got %.200s", tt.name, tt.expectedBody, rr.Body.String())
+		}
+	}
+
+	// API clients without a session need no token, but a cross-site form
+	// cannot send JSON, so it cannot log a visitor in through the API
+	for contentType, expectedStatus := range map[string]int{"application/json": http.StatusOK, "text/plain": http.StatusBadRequest} {
+		req := httptest.NewRequest("POST", "/api/login", strings.NewReader(`{"email":"admin@example.com","password":"verysecret"}`))
+		req.Header.Set("Content-Type", contentType)
+		rr := httptest.NewRecorder()
+		routes.ServeHTTP(rr, req)
+		if rr.Code != expectedStatus {
+			t.Errorf("anonymous %s login: got %v want %v (body: %.200s)", contentType, rr.Code, expectedStatus, rr.Body.String())
+		}
+	}
+}
+
+// fixed returns a step value that does not depend on earlier steps
+func fixed(s string) func() string {
+	return func() string { return s }
+}
+
+// token returns the ?token= of a mailed link
+func token(link string) string {
+	u, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	return u.Query().Get("token")
+}
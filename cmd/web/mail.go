@@ -0,0 +1,38 @@
+package main
+
+import (
+	"crypto/rand"
+	"go-breeders/internal/mailer"
+	"log"
+)
+
+// mailSender returns the configured mail sender: SMTP when -smtp-addr is
+// set, otherwise .eml files in -mail-dir, otherwise the log
+func (app *application) mailSender() mailer.Sender {
+	switch {
+	case app.config.smtpAddr != "":
+		return &mailer.SMTP{
+			Addr:     app.config.smtpAddr,
+			From:     app.config.mailFrom,
+			Username: app.config.smtpUser,
+			Password: app.config.smtpPassword,
+		}
+	case app.config.mailDir != "":
+		return &mailer.File{Dir: app.config.mailDir, From: app.config.mailFrom}
+	default:
+		return mailer.Log{}
+	}
+}
+
+// signingKey returns the key for the links in account emails and for CSRF
+// tokens. Without -secret a random key is used, so links and open forms stop
+// working when the server restarts.
+func (app *application) signingKey() []byte {
+	if app.config.secret != "" {
+		return []byte(app.config.secret)
+	}
+	log.Println("no -secret set; activation and password reset links and open forms will not survive a restart")
+	key := make([]byte, 32)
+	_, _ = rand.Read(key)
+	return key
+}
@@ -1,61 +1,150 @@
 package main
 
 import (
+	"database/sql"
 	"flag"
 	"fmt"
 	"go-breeders/internal/breeder"
 	"go-breeders/internal/cat"
+	"go-breeders/internal/database"
 	"go-breeders/internal/dog"
-	"html/template"
+	"go-breeders/internal/mailer"
+	"go-breeders/internal/onboard"
+	"go-breeders/internal/search"
+	"go-breeders/internal/signed"
+	"go-breeders/internal/user"
+	"go-breeders/internal/view"
 	"log"
 	"net/http"
+	"os"
 	"time"
 )
 
 const port = ":4000"
 
 type application struct {
-	templateMap    map[string]*template.Template
+	templates      *view.Manager
 	config         appConfig
 	DogHandler     *dog.Handler
 	CatHandler     *cat.Handler
 	BreederHandler *breeder.Handler
+	SearchHandler  *search.Handler
+	OnboardHandler *onboard.Handler
+	UserHandler    *user.Handler
+	users          *user.Service  // sessions for the HTML login pages
+	accounts       *user.Accounts // sign-up, activation and password reset
+	mail           mailer.Sender
+	csrfKey        []byte                // derives the CSRF tokens of forms
+	db             *sql.DB               // nil with the memory backend
+	dogBreedCache  *dog.CachedRepository // nil when breed caching is off
+	catBreedCache  *cat.CachedRepository // nil when breed caching is off
 }
 
 type appConfig struct {
-	useCache bool
-	dsn      string //data source name
+	useCache     bool          // preload templates; without it they are read from disk on every request
+	templateDir  string        // read templates from this directory instead of the embedded copy
+	watch        bool          // reload cached templates when files in templateDir change
+	dbDriver     string        // backend: mysql, postgres, sqlite or memory
+	dsn          string        //data source name
+	queryTimeout time.Duration // per-query database deadline
+	migrate      bool          // apply pending migrations at startup
+	pool         database.Pool // connection pool limits and startup ping retries
+	breedTTL     time.Duration // how long breed lookups are cached; zero disables the cache
+	breedEntries int           // cached breed lookups kept per species
+	sessionTTL   time.Duration // how long a login lasts
+	secret       string        // signs the links in account emails
+	baseURL      string        // where the site is reached, for links in emails
+	mailFrom     string
+	mailDir      string // write emails here instead of sending them
+	smtpAddr     string // send emails through this server
+	smtpUser     string
+	smtpPassword string
+	cookies      user.CookieConfig // SameSite and Secure attributes of the site's cookies
 }
 
 func main() {
-	app := application{
-		templateMap: make(map[string]*template.Template),
-	}
+	var app application
 
 	flag.BoolVar(&app.config.useCache, "cache", false, "Use template cache")
-	flag.StringVar(&app.config.dsn, "dsn",
-		"mariadb:myverysecretpassword@tcp(localhost:3306)/breeders?parseTime=true&tls=false&collation=utf8_unicode_ci&timeout=5s", "DSN")
+	flag.StringVar(&app.config.templateDir, "templates", "", "Read templates from this directory (defaults to ./templates without -cache or with -watch, otherwise the copy built into the binary)")
+	flag.BoolVar(&app.config.watch, "watch", false, "Reload cached templates when they change on disk (reads ./templates unless -templates is set)")
+	flag.StringVar(&app.config.dbDriver, "db-driver", "mysql", "Database driver (mysql, postgres, sqlite or memory)")
+	flag.StringVar(&app.config.dbDriver, "db", "mysql", "Shorthand for -db-driver")
+	flag.StringVar(&app.config.dsn, "dsn", "", "DSN; a sqlite:// or postgres:// scheme selects the driver (defaults to the docker-compose database)")
+	flag.DurationVar(&app.config.queryTimeout, "query-timeout", 3*time.Second, "Deadline for each database query")
+	flag.BoolVar(&app.config.migrate, "migrate", false, "Apply pending schema migrations at startup")
+	flag.DurationVar(&app.config.breedTTL, "breed-cache-ttl", 10*time.Minute, "How long breed lookups are cached (0 disables the cache)")
+	flag.IntVar(&app.config.breedEntries, "breed-cache-size", 256, "Cached breed lookups kept per species")
+	flag.DurationVar(&app.config.sessionTTL, "session-lifetime", user.DefaultSessionLifetime, "How long a login lasts")
+	flag.StringVar(&app.config.secret, "secret", os.Getenv(envSecret), "Key that signs activation and password reset links and CSRF tokens [$"+envSecret+"]")
+	flag.StringVar(&app.config.baseURL, "base-url", "http://localhost"+port, "Public URL of the site, for links in emails")
+	flag.StringVar(&app.config.mailFrom, "mail-from", "Go Breeders <noreply@localhost>", "From address of emails")
+	flag.StringVar(&app.config.mailDir, "mail-dir", "", "Write emails as .eml files to this directory instead of logging them")
+	flag.StringVar(&app.config.smtpAddr, "smtp-addr", "", "Send emails through this SMTP server (host:port)")
+	flag.StringVar(&app.config.smtpUser, "smtp-user", "", "SMTP username")
+	flag.StringVar(&app.config.smtpPassword, "smtp-password", os.Getenv(envSMTPPassword), "SMTP password [$"+envSMTPPassword+"]")
+	flag.Func("cookie-secure", "When cookies are Secure: auto (requests over TLS or with X-Forwarded-Proto: https), always or never", func(s string) (err error) {
+		app.config.cookies.Secure, err = user.ParseSecureMode(s)
+		return err
+	})
+	flag.Func("cookie-samesite", "SameSite attribute of cookies: lax (default), strict or none", func(s string) (err error) {
+		app.config.cookies.SameSite, err = user.ParseSameSite(s)
+		return err
+	})
+
+	// pool flags default to their BREEDERS_DB_* environment variables
+	pool := database.DefaultPool
+	flag.IntVar(&app.config.pool.MaxOpen, "db-max-open", envInt(envDBMaxOpen, pool.MaxOpen), "Maximum open database connections (0 = unlimited) [$"+envDBMaxOpen+"]")
+	flag.IntVar(&app.config.pool.MaxIdle, "db-max-idle", envInt(envDBMaxIdle, pool.MaxIdle), "Maximum idle database connections [$"+envDBMaxIdle+"]")
+	flag.DurationVar(&app.config.pool.MaxLifetime, "db-max-lifetime", envDuration(envDBMaxLifetime, pool.MaxLifetime), "Close connections after this long (0 = never) [$"+envDBMaxLifetime+"]")
+	flag.DurationVar(&app.config.pool.MaxIdleTime, "db-max-idle-time", envDuration(envDBMaxIdleTime, pool.MaxIdleTime), "Close connections idle for this long (0 = never) [$"+envDBMaxIdleTime+"]")
+	flag.IntVar(&app.config.pool.PingAttempts, "db-ping-attempts", envInt(envDBPingAttempts, pool.PingAttempts), "Pings before giving up on the database at startup [$"+envDBPingAttempts+"]")
+	flag.DurationVar(&app.config.pool.PingBackoff, "db-ping-backoff", envDuration(envDBPingBackoff, pool.PingBackoff), "Wait after the first failed ping, doubling each time [$"+envDBPingBackoff+"]")
 	flag.Parse()
 
-	db, err := initMySQLDB(app.config.dsn)
+	if err := app.loadTemplates(); err != nil {
+		log.Fatal(err)
+	}
+
+	repos, err := app.openRepositories()
 	if err != nil {
 		log.Panic(err)
 	}
 
+	// Breeds are reference data, so lookups are served from a cache
+	if app.config.breedTTL > 0 {
+		app.dogBreedCache = dog.NewCachedRepository(repos.Dogs, app.config.breedTTL, app.config.breedEntries)
+		app.catBreedCache = cat.NewCachedRepository(repos.Cats, app.config.breedTTL, app.config.breedEntries)
+		repos.Dogs, repos.Cats = app.dogBreedCache, app.catBreedCache
+	}
+
+	// Wire up Breeder domain first; dogs and cats validate breeder IDs against it
+	breederService := breeder.NewService(repos.Breeders)
+	app.BreederHandler = breeder.NewHandler(breederService)
+
 	// Wire up Dog domain (Repository -> Service -> Handler)
-	dogRepo := dog.NewMySQLRepository(db)
-	dogService := dog.NewService(dogRepo)
+	dogService := dog.NewService(repos.Dogs, repos.Breeders)
 	app.DogHandler = dog.NewHandler(dogService)
 
 	// Wire up Cat domain
-	catRepo := cat.NewMySQLRepository(db)
-	catService := cat.NewService(catRepo)
+	catService := cat.NewService(repos.Cats, repos.Breeders)
 	app.CatHandler = cat.NewHandler(catService)
 
-	// Wire up Breeder domain
-	breederRepo := breeder.NewMySQLRepository(db)
-	breederService := breeder.NewService(breederRepo)
-	app.BreederHandler = breeder.NewHandler(breederService)
+	// Wire up breed search across both species
+	searchService := search.NewService(repos.Dogs, repos.Cats)
+	app.SearchHandler = search.NewHandler(searchService)
+
+	// Wire up bulk onboarding, which writes to all three domains in one unit of work
+	onboardService := onboard.NewService(repos.work)
+	app.OnboardHandler = onboard.NewHandler(onboardService)
+
+	// Wire up accounts and login sessions
+	app.users = user.NewService(repos.Users, app.config.sessionTTL, app.config.cookies)
+	app.UserHandler = user.NewHandler(app.users)
+	app.mail = app.mailSender()
+	key := app.signingKey()
+	app.accounts = user.NewAccounts(repos.Users, signed.New(key), app.mail, app.config.baseURL)
+	app.csrfKey = key
 
 	srv := &http.Server{
 		Addr:              port,
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"go-breeders/internal/apperr"
+	"net/http"
+)
+
+// pages are the templates /{page} serves, by URL name. Any other name is a 404.
+var pages = map[string]string{
+	"about":        "about.page.gohtml",
+	"cat-breeds":   "cat-breeds.page.gohtml",
+	"dog-breeds":   "dog-breeds.page.gohtml",
+	"cat-breeders": "cat-breeders.page.gohtml",
+	"dog-breeders": "dog-breeders.page.gohtml",
+}
+
+// Error pages, rendered through the base layout like any other page
+const (
+	notFoundPage    = "not-found.page.gohtml"
+	forbiddenPage   = "forbidden.page.gohtml"
+	serverErrorPage = "server-error.page.gohtml"
+)
+
+// checkPages confirms that every registered page and error page has a
+// template that parses, so a typo fails at startup rather than on a request
+func (app *application) checkPages() error {
+	names := []string{"home.page.gohtml", "test.page.gohtml", notFoundPage, forbiddenPage, serverErrorPage,
+		loginPage, logoutPage, registerPage, forgotPasswordPage, resetPasswordPage, messagePage}
+	for _, t := range pages {
+		names = append(names, t)
+	}
+
+	for _, t := range names {
+		if _, err := app.templates.Get(t); err != nil {
+			return fmt.Errorf("registered page: %w", err)
+		}
+	}
+	return nil
+}
+
+// notFound answers unknown routes: a JSON error under /api/, the 404 page elsewhere
+func (app *application) notFound(w http.ResponseWriter, r *http.Request) {
+	if isAPI(r) {
+		apperr.WriteJSON(w, apperr.New(apperr.NotFound, "no such endpoint"))
+		return
+	}
+	app.renderStatus(w, r, http.StatusNotFound, notFoundPage, nil)
+}
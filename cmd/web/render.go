@@ -1,63 +1,105 @@
 package main
 
 import (
-	"fmt"
-	"html/template"
+	"bytes"
+	"context"
+	"go-breeders/internal/user"
+	"go-breeders/internal/view"
+	"go-breeders/templates"
+	"io/fs"
 	"log"
 	"net/http"
+	"os"
+	"time"
 )
 
+// watchInterval is how often -watch checks the templates for changes
+const watchInterval = 500 * time.Millisecond
+
 type temmplateData struct {
-	Data map[string]any
+	Data      map[string]any
+	User      *user.User // the logged-in user, or nil
+	CSRFToken string     // the request's CSRF token; forms include it with CSRFField
 }
 
-func (app *application) render(w http.ResponseWriter, t string, td *temmplateData) {
-	var tmpl *template.Template
-
-	// template cache, try to get the template from our map , stored in the receiver
-	if app.config.useCache {
-		if templateFromMap, ok := app.templateMap[t]; ok {
-			tmpl = templateFromMap
-		}
-	}
-
-	if tmpl == nil {
-		newTemplate, err := app.buildTemplateFromDisk(t)
-		if err != nil {
-			log.Println("error building template", err)
-			return
-		}
-		log.Println("building template from disk")
-		tmpl = newTemplate
-	}
+func (app *application) render(w http.ResponseWriter, r *http.Request, t string, td *temmplateData) {
+	app.renderStatus(w, r, http.StatusOK, t, td)
+}
 
+// renderStatus renders page t with the given status. The page is executed
+// into a buffer first, so a failing template shows the 500 page rather than
+// half a page.
+func (app *application) renderStatus(w http.ResponseWriter, r *http.Request, status int, t string, td *temmplateData) {
 	if td == nil {
 		td = &temmplateData{}
 	}
+	td.User = user.FromContext(r.Context())
+	td.CSRFToken = csrfFromContext(r.Context())
 
-	if err := tmpl.ExecuteTemplate(w, t, td); err != nil {
-		log.Println("error executing template", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	var buf bytes.Buffer
+	tmpl, err := app.templates.Get(t)
+	if err == nil {
+		err = tmpl.ExecuteTemplate(&buf, t, td)
+	}
+	if err != nil {
+		app.serverError(w, err)
+		return
 	}
 
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = buf.WriteTo(w)
 }
 
-func (app *application) buildTemplateFromDisk(t string) (*template.Template, error) {
-	templateSlice := []string{
-		"./templates/base.layout.gohtml",
-		"./templates/partials/header.partial.gohtml",
-		"./templates/partials/footer.partial.gohtml",
-		fmt.Sprintf("./templates/%s", t),
+// serverError logs err and renders the 500 page, or plain text if that fails too
+func (app *application) serverError(w http.ResponseWriter, err error) {
+	log.Println("error rendering page:", err)
+
+	var buf bytes.Buffer
+	tmpl, err := app.templates.Get(serverErrorPage)
+	if err == nil {
+		err = tmpl.ExecuteTemplate(&buf, serverErrorPage, &temmplateData{})
+	}
+	if err != nil {
+		log.Println("error rendering the error page:", err)
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
 	}
 
-	tmpl, err := template.ParseFiles(templateSlice...)
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusInternalServerError)
+	_, _ = buf.WriteTo(w)
+}
 
-	if err != nil {
-		return nil, err
+// loadTemplates sets up the template manager. With -cache the embedded
+// templates are preloaded; without it ./templates is read from disk on every
+// request, so edits show up on reload. -templates names another directory,
+// and -watch reloads cached templates from it when they change.
+func (app *application) loadTemplates() error {
+	dir := app.config.templateDir
+	if dir == "" && (app.config.watch || !app.config.useCache) {
+		dir = "./templates"
 	}
 
-	app.templateMap[t] = tmpl
+	var fsys fs.FS = templates.FS
+	if dir != "" {
+		fsys = os.DirFS(dir)
+	}
 
-	return tmpl, nil
+	m, err := view.New(fsys, app.config.useCache)
+	if err != nil {
+		return err
+	}
+	app.templates = m
+	if err := app.checkPages(); err != nil {
+		return err
+	}
 
+	switch {
+	case app.config.watch && app.config.useCache:
+		go m.Watch(context.Background(), watchInterval)
+	case app.config.watch:
+		log.Println("-watch has no effect without -cache; templates are read on every request")
+	}
+	return nil
 }
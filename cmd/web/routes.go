@@ -1,6 +1,7 @@
 package main
 
 import (
+	"go-breeders/internal/user"
 	"net/http"
 	"time"
 
@@ -12,6 +13,10 @@ func (app *application) routes() http.Handler {
 	mux := chi.NewRouter()
 	mux.Use(middleware.Recoverer)
 	mux.Use(middleware.Timeout(60 * time.Second))
+	mux.Use(app.users.LoadSession)
+	mux.Use(app.users.LoadToken)
+	mux.Use(app.csrf)
+	mux.NotFound(app.notFound)
 
 	fileServer := http.FileServer(http.Dir("./static/"))
 	mux.Handle("/static/*", http.StripPrefix("/static", fileServer))
@@ -25,19 +30,85 @@ func (app *application) routes() http.Handler {
 	mux.Get("/api/dog-from-abstract-factory", app.CreateDogFromAbstractFactory)
 	mux.Get("/api/cat-from-abstract-factory", app.CreateCatFromAbstractFactory)
 
+	// account pages; registered before /{page} so they are not looked up as pages
+	mux.Get("/login", app.ShowLogin)
+	mux.Post("/login", app.Login)
+	mux.Get("/logout", app.ShowLogout)
+	mux.Post("/logout", app.Logout)
+	mux.Get("/register", app.ShowRegister)
+	mux.Post("/register", app.Register)
+	mux.Get("/activate", app.Activate)
+	mux.Get("/forgot-password", app.ShowForgotPassword)
+	mux.Post("/forgot-password", app.ForgotPassword)
+	mux.Get("/reset-password", app.ShowResetPassword)
+	mux.Post("/reset-password", app.ResetPassword)
+
 	mux.Get("/", app.ShowHome)
 	mux.Get("/{page}", app.ShowPage)
 
 	// Dog domain routes
-	mux.Get("/api/dog-breeds", app.DogHandler.GetAllBreedsJSON)
+	mux.With(conditionalGET).Get("/api/dog-breeds", app.DogHandler.GetAllBreedsJSON)
+	mux.With(conditionalGET).Get("/api/dog-breeds/{id}", app.DogHandler.GetBreedByIDJSON)
 	mux.Get("/api/dogs", app.DogHandler.GetAllDogsJSON)
+	mux.Get("/api/dogs/{id}", app.DogHandler.GetDogByIDJSON)
 
 	// Cat domain routes
-	mux.Get("/api/cat-breeds", app.CatHandler.GetAllBreedsJSON)
+	mux.With(conditionalGET).Get("/api/cat-breeds", app.CatHandler.GetAllBreedsJSON)
+	mux.With(conditionalGET).Get("/api/cat-breeds/{id}", app.CatHandler.GetBreedByIDJSON)
 	mux.Get("/api/cats", app.CatHandler.GetAllCatsJSON)
+	mux.Get("/api/cats/{id}", app.CatHandler.GetCatByIDJSON)
 
 	// Breeder domain routes
 	mux.Get("/api/breeders", app.BreederHandler.GetAllBreedersJSON)
+	mux.Get("/api/breeders/{id}", app.BreederHandler.GetBreederByIDJSON)
+
+	// Breed search across dogs and cats
+	mux.Get("/api/breeds/search", app.SearchHandler.SearchBreedsJSON)
+
+	// Accounts
+	mux.Post("/api/login", app.UserHandler.LoginJSON)
+	mux.Post("/api/logout", app.UserHandler.LogoutJSON)
+
+	// Any logged-in user may see themselves and manage their API tokens
+	mux.Group(func(mux chi.Router) {
+		mux.Use(app.requireRole(user.Viewer))
+
+		mux.Get("/api/me", app.UserHandler.MeJSON)
+		mux.Get("/api/tokens", app.UserHandler.ListTokensJSON)
+		mux.Post("/api/tokens", app.UserHandler.CreateTokenJSON)
+		mux.Delete("/api/tokens/{id}", app.UserHandler.RevokeTokenJSON)
+	})
+
+	// Writes are for breeder staff
+	mux.Group(func(mux chi.Router) {
+		mux.Use(app.requireRole(user.Staff))
+
+		mux.Post("/api/dogs", app.DogHandler.CreateDogJSON)
+		mux.Put("/api/dogs/{id}", app.DogHandler.UpdateDogJSON)
+		mux.Patch("/api/dogs/{id}", app.DogHandler.PatchDogJSON)
+		mux.Delete("/api/dogs/{id}", app.DogHandler.DeleteDogJSON)
+
+		mux.Post("/api/cats", app.CatHandler.CreateCatJSON)
+		mux.Put("/api/cats/{id}", app.CatHandler.UpdateCatJSON)
+		mux.Patch("/api/cats/{id}", app.CatHandler.PatchCatJSON)
+		mux.Delete("/api/cats/{id}", app.CatHandler.DeleteCatJSON)
+
+		mux.Post("/api/breeders", app.BreederHandler.CreateBreederJSON)
+		mux.Put("/api/breeders/{id}", app.BreederHandler.UpdateBreederJSON)
+		mux.Patch("/api/breeders/{id}", app.BreederHandler.PatchBreederJSON)
+		mux.Delete("/api/breeders/{id}", app.BreederHandler.DeleteBreederJSON)
+
+		// Register a breeder with their initial dogs and cats in one transaction
+		mux.Post("/api/onboarding", app.OnboardHandler.OnboardJSON)
+	})
+
+	// Connection pool and cache statistics are for admins
+	mux.Group(func(mux chi.Router) {
+		mux.Use(app.requireRole(user.Admin))
+
+		mux.Get("/debug/db", app.DBStats)
+		mux.Get("/debug/cache", app.CacheStats)
+	})
 
 	return mux
 }
@@ -4,37 +4,155 @@ import (
 	"go-breeders/internal/breeder"
 	"go-breeders/internal/cat"
 	"go-breeders/internal/dog"
+	"go-breeders/internal/mailer/mailertest"
+	"go-breeders/internal/onboard"
+	"go-breeders/internal/search"
+	"go-breeders/internal/signed"
+	"go-breeders/internal/uow"
+	"go-breeders/internal/user"
+	"go-breeders/internal/view"
+	"go-breeders/templates"
+	"log"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 )
 
 var testApp application
 
+// testBaseURL starts the links in the emails tests send
+const testBaseURL = "http://breeders.test"
+
+// testKey signs email links and CSRF tokens in tests
+var testKey = []byte("test")
+
 func TestMain(m *testing.M) {
 	// Setup - wire up each domain with mock repositories
 	// Repository -> Service -> Handler chain for each domain
 
+	// Breeder domain with mock
+	breederRepo := breeder.NewMockRepository()
+	breederService := breeder.NewService(breederRepo)
+	breederHandler := breeder.NewHandler(breederService)
+
 	// Dog domain with mock
 	dogRepo := dog.NewMockRepository()
-	dogService := dog.NewService(dogRepo)
+	dogService := dog.NewService(dogRepo, breederRepo)
 	dogHandler := dog.NewHandler(dogService)
 
 	// Cat domain with mock
 	catRepo := cat.NewMockRepository()
-	catService := cat.NewService(catRepo)
+	catService := cat.NewService(catRepo, breederRepo)
 	catHandler := cat.NewHandler(catService)
 
-	// Breeder domain with mock
-	breederRepo := breeder.NewMockRepository()
-	breederService := breeder.NewService(breederRepo)
-	breederHandler := breeder.NewHandler(breederService)
+	// Breed search over the dog and cat mocks
+	searchService := search.NewService(dogRepo, catRepo)
+	searchHandler := search.NewHandler(searchService)
+
+	// Onboarding over the mocks, which drop its writes
+	work := uow.NewMemory(uow.Repositories{Breeders: breederRepo, Dogs: dogRepo, Cats: catRepo})
+	onboardHandler := onboard.NewHandler(onboard.NewService(work))
+
+	// Accounts with the seeded admin
+	userRepo := user.NewMemoryRepository(user.MockFixtures())
+	userService := user.NewService(userRepo, 0, user.CookieConfig{})
+	outbox := &mailertest.Outbox{}
+
+	// Templates from the embedded copy, preloaded as in production
+	pages, err := view.New(templates.FS, true)
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	testApp = application{
+		templates:      pages,
 		DogHandler:     dogHandler,
 		CatHandler:     catHandler,
 		BreederHandler: breederHandler,
+		SearchHandler:  searchHandler,
+		OnboardHandler: onboardHandler,
+		UserHandler:    user.NewHandler(userService),
+		users:          userService,
+		accounts:       user.NewAccounts(userRepo, signed.New(testKey), outbox, testBaseURL),
+		mail:           outbox,
+		csrfKey:        testKey,
 	}
 
 	// Run all tests
 	os.Exit(m.Run())
 }
+
+// newMemoryApp returns an application backed by fresh in-memory repositories,
+// for tests that need writes to be visible to later reads
+func newMemoryApp() application {
+	breederRepo := breeder.NewMemoryRepository(breeder.MockFixtures())
+	dogRepo := dog.NewMemoryRepository(breederRepo, dog.MockFixtures())
+	catRepo := cat.NewMemoryRepository(breederRepo, cat.MockFixtures())
+	userRepo := user.NewMemoryRepository(user.MockFixtures())
+	work := uow.NewMemory(uow.Repositories{Breeders: breederRepo, Dogs: dogRepo, Cats: catRepo, Users: userRepo})
+	users := user.NewService(userRepo, 0, user.CookieConfig{})
+	outbox := &mailertest.Outbox{}
+
+	return application{
+		templates:      testApp.templates,
+		DogHandler:     dog.NewHandler(dog.NewService(dogRepo, breederRepo)),
+		CatHandler:     cat.NewHandler(cat.NewService(catRepo, breederRepo)),
+		BreederHandler: breeder.NewHandler(breeder.NewService(breederRepo)),
+		SearchHandler:  search.NewHandler(search.NewService(dogRepo, catRepo)),
+		OnboardHandler: onboard.NewHandler(onboard.NewService(work)),
+		UserHandler:    user.NewHandler(users),
+		users:          users,
+		accounts:       user.NewAccounts(userRepo, signed.New(testKey), outbox, testBaseURL),
+		mail:           outbox,
+		csrfKey:        testKey,
+	}
+}
+
+// asUser serves requests through next as if a user with role had logged in
+// in a browser that sends the CSRF token
+func asUser(role user.Role, next http.Handler) http.Handler {
+	u := &user.User{ID: 1, Email: role.String() + "@example.com", UserActive: 1, AccessLevel: int(role)}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.AddCookie(&http.Cookie{Name: csrfCookie, Value: "secret"})
+		r.Header.Set(csrfHeader, csrfToken(testKey, "secret"))
+		next.ServeHTTP(w, r.WithContext(user.WithUser(r.Context(), u)))
+	})
+}
+
+// browser sends requests to routes the way a browser would: it keeps the
+// cookies it is given and sends the CSRF token from the last response that
+// had one. Cleared cookies are kept, so requests after a logout replay the
+// old session.
+type browser struct {
+	routes  http.Handler
+	cookies map[string]*http.Cookie
+	csrf    string
+}
+
+func newBrowser(routes http.Handler) *browser {
+	return &browser{routes: routes, cookies: make(map[string]*http.Cookie)}
+}
+
+// do sends req with the browser's cookies and CSRF token
+func (b *browser) do(req *http.Request) *httptest.ResponseRecorder {
+	for _, c := range b.cookies {
+		req.AddCookie(c)
+	}
+	if b.csrf != "" && req.Header.Get(csrfHeader) == "" {
+		req.Header.Set(csrfHeader, b.csrf)
+	}
+	rr := httptest.NewRecorder()
+
+	b.routes.ServeHTTP(rr, req)
+
+	for _, c := range rr.Result().Cookies() {
+		if c.MaxAge >= 0 {
+			b.cookies[c.Name] = c
+		}
+	}
+	if token := rr.Header().Get(csrfHeader); token != "" {
+		b.csrf = token
+	}
+	return rr
+}
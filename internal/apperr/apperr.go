@@ -0,0 +1,99 @@
+package apperr
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kind classifies an error so handlers can react to it without knowing
+// which repository implementation produced it
+type Kind uint8
+
+const (
+	Internal Kind = iota
+	BadRequest
+	NotFound
+	Conflict
+	Validation
+	Unavailable
+	Unauthorized
+	Forbidden
+)
+
+// String returns the stable code sent to clients in the error envelope
+func (k Kind) String() string {
+	switch k {
+	case BadRequest:
+		return "bad_request"
+	case NotFound:
+		return "not_found"
+	case Conflict:
+		return "conflict"
+	case Validation:
+		return "validation"
+	case Unavailable:
+		return "unavailable"
+	case Unauthorized:
+		return "unauthorized"
+	case Forbidden:
+		return "forbidden"
+	default:
+		return "internal"
+	}
+}
+
+// FieldError describes a single invalid field in a request body
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error is a domain error with a Kind and a message that is safe to show clients
+type Error struct {
+	Kind    Kind
+	Message string
+	Fields  []FieldError // per-field problems, set on Validation errors
+	Err     error        // underlying cause, logged but never sent to clients
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Err)
+	}
+	return e.Message
+}
+
+// Unwrap exposes the underlying cause to errors.Is and errors.As
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// New creates an error of the given kind
+func New(kind Kind, message string) *Error {
+	return &Error{Kind: kind, Message: message}
+}
+
+// Wrap creates an error of the given kind around an underlying cause
+func Wrap(kind Kind, err error, message string) *Error {
+	return &Error{Kind: kind, Message: message, Err: err}
+}
+
+// NotFoundf creates a NotFound error, e.g. NotFoundf("dog %d not found", id)
+func NotFoundf(format string, args ...any) *Error {
+	return New(NotFound, fmt.Sprintf(format, args...))
+}
+
+// KindOf returns the Kind of the first *Error in err's chain, or Internal
+func KindOf(err error) Kind {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+	return Internal
+}
+
+// Is reports whether err carries the given kind
+func Is(err error, kind Kind) bool {
+	return err != nil && KindOf(err) == kind
+}
@@ -0,0 +1,118 @@
+package apperr
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+)
+
+func TestFromSQL(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedKind   Kind
+		expectedStatus int
+	}{
+		{"no rows", sql.ErrNoRows, NotFound, http.StatusNotFound},
+		{"wrapped no rows", fmt.Errorf("scan: %w", sql.ErrNoRows), NotFound, http.StatusNotFound},
+		{"duplicate entry", &mysql.MySQLError{Number: 1062}, Conflict, http.StatusConflict},
+		{"row referenced", &mysql.MySQLError{Number: 1451}, Conflict, http.StatusConflict},
+		{"missing foreign key", &mysql.MySQLError{Number: 1452}, Validation, http.StatusUnprocessableEntity},
+		{"deadlock", &mysql.MySQLError{Number: 1213}, Unavailable, http.StatusServiceUnavailable},
+		{"pg unique violation", &pq.Error{Code: "23505"}, Conflict, http.StatusConflict},
+		{"pg missing foreign key", &pq.Error{Code: "23503", Detail: `Key (breed_id)=(9) is not present in table "dog_breeds".`}, Validation, http.StatusUnprocessableEntity},
+		{"pg row referenced", &pq.Error{Code: "23503", Detail: `Key (id)=(1) is still referenced from table "dogs".`}, Conflict, http.StatusConflict},
+		{"pg deadlock", &pq.Error{Code: "40P01"}, Unavailable, http.StatusServiceUnavailable},
+		{"deadline", context.DeadlineExceeded, Unavailable, http.StatusServiceUnavailable},
+		{"conn done", sql.ErrConnDone, Unavailable, http.StatusServiceUnavailable},
+		{"unknown", errors.New("boom"), Internal, http.StatusInternalServerError},
+		{"already domain error", NotFoundf("dog 1 not found"), NotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := FromSQL(tt.err, "dog")
+
+			if kind := KindOf(err); kind != tt.expectedKind {
+				t.Errorf("wrong kind: got %v want %v", kind, tt.expectedKind)
+			}
+
+			if status := HTTPStatus(err); status != tt.expectedStatus {
+				t.Errorf("wrong status: got %v want %v", status, tt.expectedStatus)
+			}
+		})
+	}
+
+	if FromSQL(nil, "dog") != nil {
+		t.Error("expected nil error to stay nil")
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"deadlock", &mysql.MySQLError{Number: 1213}, true},
+		{"lock wait timeout", &mysql.MySQLError{Number: 1205}, true},
+		{"wrapped deadlock", FromSQL(&mysql.MySQLError{Number: 1213}, "dog"), true},
+		{"duplicate entry", &mysql.MySQLError{Number: 1062}, false},
+		{"pg serialization failure", &pq.Error{Code: "40001"}, true},
+		{"pg deadlock", &pq.Error{Code: "40P01"}, true},
+		{"pg unique violation", &pq.Error{Code: "23505"}, false},
+		{"validation", New(Validation, "validation failed"), false},
+		{"nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Retryable(tt.err); got != tt.expected {
+				t.Errorf("got %v want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	tests := []struct {
+		name            string
+		err             error
+		expectedStatus  int
+		expectedCode    string
+		expectedMessage string
+	}{
+		{"not found", NotFoundf("dog %d not found", 7), http.StatusNotFound, "not_found", "dog 7 not found"},
+		{"wrapped", fmt.Errorf("service: %w", New(Conflict, "dog already exists")), http.StatusConflict, "conflict", "dog already exists"},
+		{"internal hides cause", FromSQL(errors.New("dial tcp: secret host"), "dog"), http.StatusInternalServerError, "internal", "internal server error"},
+		{"plain error", errors.New("boom"), http.StatusInternalServerError, "internal", "internal server error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			WriteJSON(rr, tt.err)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("wrong status: got %v want %v", rr.Code, tt.expectedStatus)
+			}
+
+			var resp Response
+			if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+				t.Fatal(err)
+			}
+
+			if !resp.Error || resp.Code != tt.expectedCode || resp.Message != tt.expectedMessage {
+				t.Errorf("wrong envelope: got %+v", resp)
+			}
+		})
+	}
+}
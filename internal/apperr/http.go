@@ -0,0 +1,66 @@
+package apperr
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/tsawler/toolbox"
+)
+
+// Response is the JSON error envelope returned by every API endpoint.
+// Error and Message keep the shape of toolbox.JSONResponse so existing
+// clients keep working; Code is one of the Kind strings.
+type Response struct {
+	Error   bool         `json:"error"`
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Fields  []FieldError `json:"fields,omitempty"`
+}
+
+// HTTPStatus maps an error to the HTTP status code for its kind
+func HTTPStatus(err error) int {
+	switch KindOf(err) {
+	case BadRequest:
+		return http.StatusBadRequest
+	case NotFound:
+		return http.StatusNotFound
+	case Conflict:
+		return http.StatusConflict
+	case Validation:
+		return http.StatusUnprocessableEntity
+	case Unavailable:
+		return http.StatusServiceUnavailable
+	case Unauthorized:
+		return http.StatusUnauthorized
+	case Forbidden:
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// WriteJSON writes err to w using the status and envelope for its kind.
+// Internal errors are logged and replaced with a generic message.
+func WriteJSON(w http.ResponseWriter, err error) {
+	var t toolbox.Tools
+
+	resp := Response{
+		Error:   true,
+		Code:    Internal.String(),
+		Message: "internal server error",
+	}
+
+	var e *Error
+	if errors.As(err, &e) && e.Kind != Internal {
+		resp.Code = e.Kind.String()
+		resp.Message = e.Message
+		resp.Fields = e.Fields
+	}
+
+	if kind := KindOf(err); kind == Internal || kind == Unavailable {
+		log.Println("error handling request:", err)
+	}
+
+	_ = t.WriteJSON(w, HTTPStatus(err), resp)
+}
@@ -0,0 +1,151 @@
+package apperr
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"modernc.org/sqlite"
+)
+
+// MySQL server error numbers we translate into domain errors
+const (
+	mysqlDuplicateEntry     = 1062
+	mysqlRowIsReferenced    = 1451
+	mysqlNoReferencedRow    = 1452
+	mysqlLockWaitTimeout    = 1205
+	mysqlDeadlock           = 1213
+	mysqlTooManyConnections = 1040
+)
+
+// PostgreSQL SQLSTATE codes we translate into domain errors
+const (
+	pgUniqueViolation      = "23505"
+	pgForeignKeyViolation  = "23503"
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+	pgTooManyConnections   = "53300"
+	pgLockNotAvailable     = "55P03"
+)
+
+// SQLite result codes we translate into domain errors
+const (
+	sqliteBusy                 = 5
+	sqliteLocked               = 6
+	sqliteForeignKeyConstraint = 787
+	sqlitePrimaryKeyConstraint = 1555
+	sqliteUniqueConstraint     = 2067
+)
+
+// FromSQL translates database/sql and driver errors into domain errors.
+// resource names the record involved ("dog", "cat breed") and is used to
+// build client-facing messages. Errors that are already *Error pass through.
+func FromSQL(err error, resource string) error {
+	if err == nil {
+		return nil
+	}
+
+	var e *Error
+	if errors.As(err, &e) {
+		return err
+	}
+
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return Wrap(NotFound, err, resource+" not found")
+	case errors.Is(err, context.DeadlineExceeded),
+		errors.Is(err, context.Canceled),
+		errors.Is(err, driver.ErrBadConn),
+		errors.Is(err, sql.ErrConnDone):
+		return Wrap(Unavailable, err, "database unavailable")
+	}
+
+	var myErr *mysql.MySQLError
+	if errors.As(err, &myErr) {
+		switch myErr.Number {
+		case mysqlDuplicateEntry:
+			return Wrap(Conflict, err, resource+" already exists")
+		case mysqlRowIsReferenced:
+			return Wrap(Conflict, err, resource+" is still referenced by other records")
+		case mysqlNoReferencedRow:
+			return Wrap(Validation, err, resource+" references a record that does not exist")
+		case mysqlLockWaitTimeout, mysqlDeadlock, mysqlTooManyConnections:
+			return Wrap(Unavailable, err, "database unavailable")
+		}
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case pgUniqueViolation:
+			return Wrap(Conflict, err, resource+" already exists")
+		case pgForeignKeyViolation:
+			// the same code covers both directions; the detail tells them apart
+			if strings.Contains(pqErr.Detail, "is still referenced") {
+				return Wrap(Conflict, err, resource+" is still referenced by other records")
+			}
+			return Wrap(Validation, err, resource+" references a record that does not exist")
+		case pgSerializationFailure, pgDeadlockDetected, pgTooManyConnections, pgLockNotAvailable:
+			return Wrap(Unavailable, err, "database unavailable")
+		}
+	}
+
+	var liteErr *sqlite.Error
+	if errors.As(err, &liteErr) {
+		// extended codes carry the primary code in the low byte
+		switch code := liteErr.Code(); {
+		case code == sqliteUniqueConstraint, code == sqlitePrimaryKeyConstraint:
+			return Wrap(Conflict, err, resource+" already exists")
+		case code == sqliteForeignKeyConstraint:
+			return Wrap(Validation, err, resource+" references a record that does not exist")
+		case code&0xff == sqliteBusy, code&0xff == sqliteLocked:
+			return Wrap(Unavailable, err, "database unavailable")
+		}
+	}
+
+	return Wrap(Internal, err, "internal server error")
+}
+
+// Retryable reports whether err is a deadlock, serialization failure or busy
+// database, after which the whole transaction may succeed if run again
+func Retryable(err error) bool {
+	var myErr *mysql.MySQLError
+	if errors.As(err, &myErr) {
+		return myErr.Number == mysqlDeadlock || myErr.Number == mysqlLockWaitTimeout
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == pgSerializationFailure || pqErr.Code == pgDeadlockDetected
+	}
+
+	var liteErr *sqlite.Error
+	if errors.As(err, &liteErr) {
+		code := liteErr.Code() & 0xff
+		return code == sqliteBusy || code == sqliteLocked
+	}
+
+	return false
+}
+
+// FromResult is FromSQL for UPDATE and DELETE statements: a statement that
+// matched no rows means the record does not exist. MySQL connections need
+// clientFoundRows=true so that updates which change nothing still count.
+func FromResult(result sql.Result, err error, resource string) error {
+	if err != nil {
+		return FromSQL(err, resource)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return FromSQL(err, resource)
+	}
+	if n == 0 {
+		return New(NotFound, resource+" not found")
+	}
+	return nil
+}
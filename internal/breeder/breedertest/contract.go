@@ -0,0 +1,264 @@
+// Package breedertest is a conformance suite for breeder.Repository implementations,
+// so the SQL, in-memory and mock repositories are held to the same behaviour.
+package breedertest
+
+import (
+	"context"
+	"go-breeders/internal/apperr"
+	"go-breeders/internal/breeder"
+	"go-breeders/internal/list"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+// missingID is an ID no repository is expected to hold
+const missingID = 987654321
+
+// Factory returns a fresh repository for each subtest. The repository must
+// hold at least two breeders.
+type Factory func(t *testing.T) breeder.Repository
+
+// RunReadContract checks ordering, paging, filtering and not-found
+// behaviour. It suits fakes such as breeder.MockRepository that drop writes.
+func RunReadContract(t *testing.T, factory Factory) {
+	ctx := context.Background()
+
+	t.Run("breeders sorted by name by default", func(t *testing.T) {
+		breeders := allBreeders(t, factory(t), list.Options{})
+		for i := 1; i < len(breeders); i++ {
+			if strings.ToLower(breeders[i-1].BreederName) > strings.ToLower(breeders[i].BreederName) {
+				t.Fatalf("%q sorted before %q", breeders[i-1].BreederName, breeders[i].BreederName)
+			}
+		}
+	})
+
+	t.Run("breeders sorted by ID descending", func(t *testing.T) {
+		breeders := allBreeders(t, factory(t), list.Options{Sort: "id", Desc: true})
+		for i := 1; i < len(breeders); i++ {
+			if breeders[i-1].ID < breeders[i].ID {
+				t.Fatalf("%d sorted before %d", breeders[i-1].ID, breeders[i].ID)
+			}
+		}
+	})
+
+	t.Run("breeders paged", func(t *testing.T) {
+		repo := factory(t)
+		first, total, err := repo.AllBreeders(ctx, list.Options{Page: 1, PerPage: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		second, total2, err := repo.AllBreeders(ctx, list.Options{Page: 2, PerPage: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(first) != 2 || len(second) != 1 {
+			t.Fatalf("expected pages of 2 and 1, got %d and %d", len(first), len(second))
+		}
+		if second[0].ID != first[1].ID {
+			t.Errorf("page 2 of size 1 returned breeder %d, want %d", second[0].ID, first[1].ID)
+		}
+		if total != total2 || total < 2 {
+			t.Errorf("totals differ between pages: %d and %d", total, total2)
+		}
+	})
+
+	t.Run("breeders filtered by active", func(t *testing.T) {
+		repo := factory(t)
+		active := allBreeders(t, repo, list.Options{})[0].Active
+
+		filtered, _, err := repo.AllBreeders(ctx, list.Options{Page: 1, PerPage: list.MaxPerPage, Filters: list.Filters{Active: &active}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(filtered) == 0 {
+			t.Fatalf("no breeders returned for active=%d", active)
+		}
+		for _, b := range filtered {
+			if b.Active != active {
+				t.Errorf("%s has active=%d, filtered on %d", b.BreederName, b.Active, active)
+			}
+		}
+	})
+
+	t.Run("breeders filtered by place ignoring case", func(t *testing.T) {
+		repo := factory(t)
+		want := allBreeders(t, repo, list.Options{})[0]
+
+		for _, tt := range []struct {
+			name    string
+			value   string
+			filters list.Filters
+		}{
+			{"city", want.City, list.Filters{City: swapCase(want.City)}},
+			{"prov_state", want.ProvState, list.Filters{ProvState: swapCase(want.ProvState)}},
+			{"country", want.Country, list.Filters{Country: swapCase(want.Country)}},
+		} {
+			if tt.value == "" {
+				continue
+			}
+			filtered, _, err := repo.AllBreeders(ctx, list.Options{Page: 1, PerPage: list.MaxPerPage, Filters: tt.filters})
+			if err != nil {
+				t.Fatal(err)
+			}
+			found := false
+			for _, b := range filtered {
+				found = found || b.ID == want.ID
+			}
+			if !found {
+				t.Errorf("%s with %s %q was not returned for %q", want.BreederName, tt.name, tt.value, swapCase(tt.value))
+			}
+		}
+	})
+
+	t.Run("breeder found by ID", func(t *testing.T) {
+		repo := factory(t)
+		want := allBreeders(t, repo, list.Options{})[0]
+		got, err := repo.GetBreederByID(ctx, want.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectSame(t, got, want)
+	})
+
+	t.Run("breeder not found", func(t *testing.T) {
+		_, err := factory(t).GetBreederByID(ctx, missingID)
+		expectKind(t, err, apperr.NotFound)
+	})
+
+	t.Run("update missing breeder", func(t *testing.T) {
+		err := factory(t).UpdateBreeder(ctx, &breeder.Breeder{ID: missingID, BreederName: "Ghost Kennels"})
+		expectKind(t, err, apperr.NotFound)
+	})
+
+	t.Run("delete missing breeder", func(t *testing.T) {
+		expectKind(t, factory(t).DeleteBreeder(ctx, missingID), apperr.NotFound)
+	})
+}
+
+// RunRepositoryContract runs RunReadContract plus insert, update and
+// delete round trips
+func RunRepositoryContract(t *testing.T, factory Factory) {
+	ctx := context.Background()
+
+	RunReadContract(t, factory)
+
+	t.Run("insert round trip", func(t *testing.T) {
+		repo := factory(t)
+		want := NewBreeder("Contract Kennels")
+		want.ID = Insert(t, repo, want)
+
+		got, err := repo.GetBreederByID(ctx, want.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectSame(t, got, want)
+	})
+
+	t.Run("inserts get distinct IDs", func(t *testing.T) {
+		repo := factory(t)
+		first := Insert(t, repo, NewBreeder("One"))
+		second := Insert(t, repo, NewBreeder("Two"))
+		if first <= 0 || second <= 0 || first == second {
+			t.Errorf("expected distinct positive IDs, got %d and %d", first, second)
+		}
+	})
+
+	t.Run("update round trip", func(t *testing.T) {
+		repo := factory(t)
+		want := NewBreeder("Contract Kennels")
+		want.ID = Insert(t, repo, want)
+
+		want.BreederName, want.City, want.Active = "Renamed Kennels", "Victoria", 0
+		if err := repo.UpdateBreeder(ctx, want); err != nil {
+			t.Fatal(err)
+		}
+		got, err := repo.GetBreederByID(ctx, want.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectSame(t, got, want)
+
+		// rewriting identical values still finds the row
+		if err := repo.UpdateBreeder(ctx, want); err != nil {
+			t.Errorf("unchanged update: %v", err)
+		}
+	})
+
+	t.Run("delete removes the breeder", func(t *testing.T) {
+		repo := factory(t)
+		id := Insert(t, repo, NewBreeder("Contract Kennels"))
+
+		if err := repo.DeleteBreeder(ctx, id); err != nil {
+			t.Fatal(err)
+		}
+		_, err := repo.GetBreederByID(ctx, id)
+		expectKind(t, err, apperr.NotFound)
+		expectKind(t, repo.DeleteBreeder(ctx, id), apperr.NotFound)
+	})
+}
+
+// NewBreeder returns an active breeder with every field filled in
+func NewBreeder(name string) *breeder.Breeder {
+	return &breeder.Breeder{
+		BreederName: name,
+		Address:     "1 Contract Way",
+		City:        "Halifax",
+		ProvState:   "NS",
+		Country:     "Canada",
+		Zip:         "B3H 1A1",
+		Phone:       "902-555-0100",
+		Email:       "contract@example.com",
+		Active:      1,
+	}
+}
+
+// Insert adds b and deletes it again when the test ends, since SQL
+// backends may be shared between runs
+func Insert(t *testing.T, repo breeder.Repository, b *breeder.Breeder) int {
+	t.Helper()
+	id, err := repo.InsertBreeder(context.Background(), b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { repo.DeleteBreeder(context.Background(), id) })
+	return id
+}
+
+func allBreeders(t *testing.T, repo breeder.Repository, opts list.Options) []*breeder.Breeder {
+	t.Helper()
+	opts.Page, opts.PerPage = 1, list.MaxPerPage
+	breeders, _, err := repo.AllBreeders(context.Background(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(breeders) < 2 {
+		t.Fatalf("expected at least 2 breeders, got %d", len(breeders))
+	}
+	return breeders
+}
+
+func expectKind(t *testing.T, err error, kind apperr.Kind) {
+	t.Helper()
+	if !apperr.Is(err, kind) {
+		t.Errorf("expected a %v error, got %v", kind, err)
+	}
+}
+
+func expectSame(t *testing.T, got, want *breeder.Breeder) {
+	t.Helper()
+	if *got != *want {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+// swapCase flips the case of every letter in s, so "Thailand" becomes
+// "tHAILAND", for checking that text filters ignore case
+func swapCase(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsUpper(r) {
+			return unicode.ToLower(r)
+		}
+		return unicode.ToUpper(r)
+	}, s)
+}
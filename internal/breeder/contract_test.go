@@ -0,0 +1,49 @@
+package breeder_test
+
+import (
+	"go-breeders/internal/breeder"
+	"go-breeders/internal/breeder/breedertest"
+	"go-breeders/internal/database/databasetest"
+	"testing"
+)
+
+func TestMockRepository_Contract(t *testing.T) {
+	breedertest.RunReadContract(t, func(t *testing.T) breeder.Repository {
+		return breeder.NewMockRepository()
+	})
+}
+
+func TestMemoryRepository_Contract(t *testing.T) {
+	breedertest.RunRepositoryContract(t, func(t *testing.T) breeder.Repository {
+		return breeder.NewMemoryRepository(breeder.MockFixtures())
+	})
+}
+
+func TestSQLiteRepository_Contract(t *testing.T) {
+	breedertest.RunRepositoryContract(t, func(t *testing.T) breeder.Repository {
+		return seeded(t, breeder.NewSQLiteRepository(databasetest.SQLite(t), 0))
+	})
+}
+
+func TestMySQLRepository_Contract(t *testing.T) {
+	breedertest.RunRepositoryContract(t, func(t *testing.T) breeder.Repository {
+		return seeded(t, breeder.NewMySQLRepository(databasetest.MySQL(t), 0))
+	})
+}
+
+func TestPostgresRepository_Contract(t *testing.T) {
+	breedertest.RunRepositoryContract(t, func(t *testing.T) breeder.Repository {
+		return seeded(t, breeder.NewPostgresRepository(databasetest.Postgres(t), 0))
+	})
+}
+
+// seeded adds the two breeders the read contract needs, one of them
+// inactive; the migrations seed breeds but no breeders
+func seeded(t *testing.T, repo breeder.Repository) breeder.Repository {
+	t.Helper()
+	breedertest.Insert(t, repo, breedertest.NewBreeder("Contract Kennels"))
+	inactive := breedertest.NewBreeder("Dormant Kennels")
+	inactive.Active = 0
+	breedertest.Insert(t, repo, inactive)
+	return repo
+}
@@ -1,11 +1,19 @@
 package breeder
 
 import (
+	"fmt"
+	"go-breeders/internal/apperr"
+	"go-breeders/internal/list"
 	"net/http"
+	"strconv"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/tsawler/toolbox"
 )
 
+// maxJSONSize caps the request body accepted by the write endpoints (1 MB)
+const maxJSONSize = 1 << 20
+
 // Handler handles HTTP requests for breeder domain
 type Handler struct {
 	service *Service
@@ -16,15 +24,144 @@ func NewHandler(service *Service) *Handler {
 	return &Handler{service: service}
 }
 
-// GetAllBreedersJSON returns all breeders as JSON
+// GetAllBreedersJSON returns one page of breeders as JSON.
+// Supports ?page=, ?per_page=, ?sort= (prefix with - for descending) and filters.
 func (h *Handler) GetAllBreedersJSON(w http.ResponseWriter, r *http.Request) {
 	var t toolbox.Tools
 
-	breeders, err := h.service.GetAllBreeders()
+	opts, err := list.Parse(r, BreederSortFields)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	breeders, total, err := h.service.GetAllBreeders(r.Context(), opts)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	_ = t.WriteJSON(w, http.StatusOK, list.NewPage(breeders, total, opts))
+}
+
+// GetBreederByIDJSON returns the breeder identified by the {id} URL param as JSON
+func (h *Handler) GetBreederByIDJSON(w http.ResponseWriter, r *http.Request) {
+	var t toolbox.Tools
+
+	id, err := idParam(r)
 	if err != nil {
-		_ = t.ErrorJSON(w, err, http.StatusInternalServerError)
+		apperr.WriteJSON(w, err)
 		return
 	}
 
-	_ = t.WriteJSON(w, http.StatusOK, breeders)
+	breeder, err := h.service.GetBreederByID(r.Context(), id)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	_ = t.WriteJSON(w, http.StatusOK, breeder)
+}
+
+// CreateBreederJSON creates a breeder from the JSON body and returns it with a Location header
+func (h *Handler) CreateBreederJSON(w http.ResponseWriter, r *http.Request) {
+	t := toolbox.Tools{MaxJSONSize: maxJSONSize}
+
+	var breeder Breeder
+	if err := t.ReadJSON(w, r, &breeder); err != nil {
+		apperr.WriteJSON(w, apperr.New(apperr.BadRequest, err.Error()))
+		return
+	}
+
+	id, err := h.service.CreateBreeder(r.Context(), &breeder)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+	breeder.ID = id
+
+	headers := make(http.Header)
+	headers.Set("Location", fmt.Sprintf("/api/breeders/%d", id))
+	_ = t.WriteJSON(w, http.StatusCreated, breeder, headers)
+}
+
+// UpdateBreederJSON replaces the breeder identified by the {id} URL param with the JSON body
+func (h *Handler) UpdateBreederJSON(w http.ResponseWriter, r *http.Request) {
+	t := toolbox.Tools{MaxJSONSize: maxJSONSize}
+
+	id, err := idParam(r)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	var breeder Breeder
+	if err := t.ReadJSON(w, r, &breeder); err != nil {
+		apperr.WriteJSON(w, apperr.New(apperr.BadRequest, err.Error()))
+		return
+	}
+	breeder.ID = id
+
+	if err := h.service.UpdateBreeder(r.Context(), &breeder); err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	_ = t.WriteJSON(w, http.StatusOK, breeder)
+}
+
+// PatchBreederJSON applies the fields present in the JSON body to an existing breeder
+func (h *Handler) PatchBreederJSON(w http.ResponseWriter, r *http.Request) {
+	t := toolbox.Tools{MaxJSONSize: maxJSONSize}
+
+	id, err := idParam(r)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	breeder, err := h.service.GetBreederByID(r.Context(), id)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	// decoding onto the stored breeder leaves fields absent from the body untouched
+	if err := t.ReadJSON(w, r, breeder); err != nil {
+		apperr.WriteJSON(w, apperr.New(apperr.BadRequest, err.Error()))
+		return
+	}
+	breeder.ID = id
+
+	if err := h.service.UpdateBreeder(r.Context(), breeder); err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	_ = t.WriteJSON(w, http.StatusOK, breeder)
+}
+
+// DeleteBreederJSON deletes the breeder identified by the {id} URL param
+func (h *Handler) DeleteBreederJSON(w http.ResponseWriter, r *http.Request) {
+	id, err := idParam(r)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	if err := h.service.DeleteBreeder(r.Context(), id); err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// idParam parses the {id} URL param as a positive integer
+func idParam(r *http.Request) (int, error) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id < 1 {
+		return 0, apperr.New(apperr.BadRequest, "invalid id")
+	}
+	return id, nil
 }
@@ -0,0 +1,125 @@
+package breeder
+
+import (
+	"context"
+	"go-breeders/internal/apperr"
+	"go-breeders/internal/list"
+	"maps"
+	"sync"
+)
+
+// MemoryRepository is a thread-safe in-memory implementation of Repository.
+// It stores copies, so callers may modify what they pass in or get back.
+type MemoryRepository struct {
+	mu       sync.RWMutex
+	breeders map[int]*Breeder
+	nextID   int
+	onDelete []func(id int)
+}
+
+// NewMemoryRepository creates an in-memory repository for breeders seeded with fixtures
+func NewMemoryRepository(fixtures []*Breeder) Repository {
+	r := &MemoryRepository{
+		breeders: make(map[int]*Breeder),
+		nextID:   1,
+	}
+	for _, b := range fixtures {
+		breeder := *b
+		r.breeders[b.ID] = &breeder
+		r.nextID = max(r.nextID, b.ID+1)
+	}
+	return r
+}
+
+// AllBreeders returns one page of breeders and the total number of matches
+func (r *MemoryRepository) AllBreeders(ctx context.Context, opts list.Options) ([]*Breeder, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	breeders, total := list.Apply(list.ByID(r.breeders), opts, keepBreeder(opts.Filters), breederSortKey, "breeder_name")
+	for i, b := range breeders {
+		breeder := *b
+		breeders[i] = &breeder
+	}
+	return breeders, total, nil
+}
+
+// GetBreederByID returns a single breeder by ID
+func (r *MemoryRepository) GetBreederByID(ctx context.Context, id int) (*Breeder, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	b, ok := r.breeders[id]
+	if !ok {
+		return nil, apperr.NotFoundf("breeder %d not found", id)
+	}
+	breeder := *b
+	return &breeder, nil
+}
+
+// InsertBreeder stores a copy of breeder under the next ID and returns the ID
+func (r *MemoryRepository) InsertBreeder(ctx context.Context, breeder *Breeder) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b := *breeder
+	b.ID = r.nextID
+	r.breeders[b.ID] = &b
+	r.nextID++
+
+	return b.ID, nil
+}
+
+// UpdateBreeder replaces an existing breeder
+func (r *MemoryRepository) UpdateBreeder(ctx context.Context, breeder *Breeder) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.breeders[breeder.ID]; !ok {
+		return apperr.NotFoundf("breeder %d not found", breeder.ID)
+	}
+	b := *breeder
+	r.breeders[b.ID] = &b
+
+	return nil
+}
+
+// DeleteBreeder deletes a breeder by ID
+func (r *MemoryRepository) DeleteBreeder(ctx context.Context, id int) error {
+	r.mu.Lock()
+	if _, ok := r.breeders[id]; !ok {
+		r.mu.Unlock()
+		return apperr.NotFoundf("breeder %d not found", id)
+	}
+	delete(r.breeders, id)
+	hooks := r.onDelete
+	r.mu.Unlock()
+
+	for _, fn := range hooks {
+		fn(id)
+	}
+	return nil
+}
+
+// OnDelete registers fn to be called after a breeder is deleted, so the
+// in-memory dog and cat repositories can drop their references to it as
+// ON DELETE SET NULL does in the SQL schema
+func (r *MemoryRepository) OnDelete(fn func(id int)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.onDelete = append(r.onDelete, fn)
+}
+
+// Snapshot records the repository's contents and returns a function that puts
+// them back, so a unit of work can undo its writes
+func (r *MemoryRepository) Snapshot() (restore func()) {
+	r.mu.RLock()
+	breeders, nextID := maps.Clone(r.breeders), r.nextID
+	r.mu.RUnlock()
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.breeders, r.nextID = breeders, nextID
+	}
+}
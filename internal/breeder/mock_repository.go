@@ -1,5 +1,12 @@
 package breeder
 
+import (
+	"context"
+	"go-breeders/internal/apperr"
+	"go-breeders/internal/list"
+	"strings"
+)
+
 // MockRepository is a mock implementation for testing
 type MockRepository struct{}
 
@@ -8,8 +15,19 @@ func NewMockRepository() Repository {
 	return &MockRepository{}
 }
 
-// AllBreeders returns mock breeder data
-func (m *MockRepository) AllBreeders() ([]*Breeder, error) {
+// AllBreeders returns mock breeder data, filtered, sorted and paged like MySQL
+func (m *MockRepository) AllBreeders(ctx context.Context, opts list.Options) ([]*Breeder, int, error) {
+	breeders, total := list.Apply(m.breeders(), opts, keepBreeder(opts.Filters), breederSortKey, "breeder_name")
+	return breeders, total, nil
+}
+
+// MockFixtures returns the breeders MockRepository serves, for seeding a MemoryRepository
+func MockFixtures() []*Breeder {
+	return (&MockRepository{}).breeders()
+}
+
+// breeders returns the mock breeder fixtures
+func (m *MockRepository) breeders() []*Breeder {
 	return []*Breeder{
 		{
 			ID:          1,
@@ -35,31 +53,58 @@ func (m *MockRepository) AllBreeders() ([]*Breeder, error) {
 			Email:       "contact@furryfriends.com",
 			Active:      1,
 		},
-	}, nil
+	}
 }
 
 // GetBreederByID returns a single mock breeder
-func (m *MockRepository) GetBreederByID(id int) (*Breeder, error) {
-	breeders, _ := m.AllBreeders()
-	for _, breeder := range breeders {
+func (m *MockRepository) GetBreederByID(ctx context.Context, id int) (*Breeder, error) {
+	for _, breeder := range m.breeders() {
 		if breeder.ID == id {
 			return breeder, nil
 		}
 	}
-	return nil, nil
+	return nil, apperr.NotFoundf("breeder %d not found", id)
 }
 
 // InsertBreeder simulates inserting a breeder
-func (m *MockRepository) InsertBreeder(breeder *Breeder) (int, error) {
+func (m *MockRepository) InsertBreeder(ctx context.Context, breeder *Breeder) (int, error) {
 	return 999, nil
 }
 
 // UpdateBreeder simulates updating a breeder
-func (m *MockRepository) UpdateBreeder(breeder *Breeder) error {
-	return nil
+func (m *MockRepository) UpdateBreeder(ctx context.Context, breeder *Breeder) error {
+	_, err := m.GetBreederByID(ctx, breeder.ID)
+	return err
 }
 
 // DeleteBreeder simulates deleting a breeder
-func (m *MockRepository) DeleteBreeder(id int) error {
-	return nil
+func (m *MockRepository) DeleteBreeder(ctx context.Context, id int) error {
+	_, err := m.GetBreederByID(ctx, id)
+	return err
+}
+
+// keepBreeder returns the list.Apply filter for the breeder filters in f
+func keepBreeder(f list.Filters) func(*Breeder) bool {
+	return func(b *Breeder) bool {
+		return (f.City == "" || strings.EqualFold(b.City, f.City)) &&
+			(f.ProvState == "" || strings.EqualFold(b.ProvState, f.ProvState)) &&
+			(f.Country == "" || strings.EqualFold(b.Country, f.Country)) &&
+			(f.Active == nil || b.Active == *f.Active)
+	}
+}
+
+// breederSortKey returns the value of a BreederSortFields field for list.Apply
+func breederSortKey(b *Breeder, field string) any {
+	switch field {
+	case "breeder_name":
+		return b.BreederName
+	case "city":
+		return b.City
+	case "prov_state":
+		return b.ProvState
+	case "country":
+		return b.Country
+	default:
+		return b.ID
+	}
 }
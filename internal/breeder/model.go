@@ -13,3 +13,23 @@ type Breeder struct {
 	Email       string `json:"email"`
 	Active      int    `json:"active"`
 }
+
+// Summary is the short form of a breeder embedded in dog and cat responses
+type Summary struct {
+	ID          int    `json:"id"`
+	BreederName string `json:"breeder_name"`
+	City        string `json:"city"`
+	ProvState   string `json:"prov_state"`
+	Country     string `json:"country"`
+}
+
+// Summary returns the short form of b
+func (b *Breeder) Summary() *Summary {
+	return &Summary{
+		ID:          b.ID,
+		BreederName: b.BreederName,
+		City:        b.City,
+		ProvState:   b.ProvState,
+		Country:     b.Country,
+	}
+}
@@ -1,10 +1,18 @@
 package breeder
 
+import (
+	"context"
+	"go-breeders/internal/list"
+)
+
+// BreederSortFields are the JSON fields AllBreeders can be sorted by
+var BreederSortFields = []string{"id", "breeder_name", "city", "prov_state", "country"}
+
 // Repository defines the interface for breeder data operations
 type Repository interface {
-	AllBreeders() ([]*Breeder, error)
-	GetBreederByID(id int) (*Breeder, error)
-	InsertBreeder(breeder *Breeder) (int, error)
-	UpdateBreeder(breeder *Breeder) error
-	DeleteBreeder(id int) error
+	AllBreeders(ctx context.Context, opts list.Options) ([]*Breeder, int, error)
+	GetBreederByID(ctx context.Context, id int) (*Breeder, error)
+	InsertBreeder(ctx context.Context, breeder *Breeder) (int, error)
+	UpdateBreeder(ctx context.Context, breeder *Breeder) error
+	DeleteBreeder(ctx context.Context, id int) error
 }
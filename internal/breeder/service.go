@@ -1,5 +1,10 @@
 package breeder
 
+import (
+	"context"
+	"go-breeders/internal/list"
+)
+
 // Service provides business logic for breeder operations
 type Service struct {
 	repo Repository
@@ -10,27 +15,36 @@ func NewService(repo Repository) *Service {
 	return &Service{repo: repo}
 }
 
-// GetAllBreeders returns all breeders
-func (s *Service) GetAllBreeders() ([]*Breeder, error) {
-	return s.repo.AllBreeders()
+// GetAllBreeders returns one page of breeders and the total number of matches
+func (s *Service) GetAllBreeders(ctx context.Context, opts list.Options) ([]*Breeder, int, error) {
+	return s.repo.AllBreeders(ctx, opts)
 }
 
 // GetBreederByID returns a specific breeder
-func (s *Service) GetBreederByID(id int) (*Breeder, error) {
-	return s.repo.GetBreederByID(id)
+func (s *Service) GetBreederByID(ctx context.Context, id int) (*Breeder, error) {
+	return s.repo.GetBreederByID(ctx, id)
 }
 
-// CreateBreeder creates a new breeder
-func (s *Service) CreateBreeder(breeder *Breeder) (int, error) {
-	return s.repo.InsertBreeder(breeder)
+// CreateBreeder validates and creates a new breeder
+func (s *Service) CreateBreeder(ctx context.Context, breeder *Breeder) (int, error) {
+	if err := validateBreeder(breeder); err != nil {
+		return 0, err
+	}
+	return s.repo.InsertBreeder(ctx, breeder)
 }
 
-// UpdateBreeder updates an existing breeder
-func (s *Service) UpdateBreeder(breeder *Breeder) error {
-	return s.repo.UpdateBreeder(breeder)
+// UpdateBreeder validates and updates an existing breeder
+func (s *Service) UpdateBreeder(ctx context.Context, breeder *Breeder) error {
+	if _, err := s.repo.GetBreederByID(ctx, breeder.ID); err != nil {
+		return err
+	}
+	if err := validateBreeder(breeder); err != nil {
+		return err
+	}
+	return s.repo.UpdateBreeder(ctx, breeder)
 }
 
-// DeleteBreeder deletes a breeder
-func (s *Service) DeleteBreeder(id int) error {
-	return s.repo.DeleteBreeder(id)
+// DeleteBreeder deletes a breeder; the repository reports NotFound for a missing ID
+func (s *Service) DeleteBreeder(ctx context.Context, id int) error {
+	return s.repo.DeleteBreeder(ctx, id)
 }
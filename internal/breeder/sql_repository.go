@@ -0,0 +1,189 @@
+package breeder
+
+import (
+	"context"
+	"go-breeders/internal/apperr"
+	"go-breeders/internal/database"
+	"go-breeders/internal/list"
+	"time"
+)
+
+// defaultQueryTimeout bounds each query when no timeout is configured
+const defaultQueryTimeout = 3 * time.Second
+
+// SQLRepository is the implementation of Repository for MySQL, PostgreSQL
+// and SQLite. Queries are written once; Dialect covers where they differ.
+type SQLRepository struct {
+	DB           database.DBTX    // a *sql.DB, or a *sql.Tx inside a unit of work
+	QueryTimeout time.Duration    // per-query deadline, on top of the caller's context
+	Dialect      database.Dialect // the SQL flavour of DB's driver
+}
+
+// NewMySQLRepository creates a new MySQL repository for breeders.
+// queryTimeout bounds each query; zero uses the 3 second default.
+func NewMySQLRepository(db database.DBTX, queryTimeout time.Duration) Repository {
+	return newSQLRepository(db, queryTimeout, database.MySQLDialect)
+}
+
+// NewPostgresRepository creates a new PostgreSQL repository for breeders
+func NewPostgresRepository(db database.DBTX, queryTimeout time.Duration) Repository {
+	return newSQLRepository(db, queryTimeout, database.PostgresDialect)
+}
+
+// NewSQLiteRepository creates a new SQLite repository for breeders
+func NewSQLiteRepository(db database.DBTX, queryTimeout time.Duration) Repository {
+	return newSQLRepository(db, queryTimeout, database.SQLiteDialect)
+}
+
+// newSQLRepository creates a repository whose queries are written for dialect
+func newSQLRepository(db database.DBTX, queryTimeout time.Duration, dialect database.Dialect) *SQLRepository {
+	if queryTimeout <= 0 {
+		queryTimeout = defaultQueryTimeout
+	}
+	return &SQLRepository{DB: db, QueryTimeout: queryTimeout, Dialect: dialect}
+}
+
+// withTimeout derives the context for one query. It is cancelled when the
+// caller's context is (client disconnect, server timeout) or after QueryTimeout.
+func (r *SQLRepository) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, r.QueryTimeout)
+}
+
+// AllBreeders returns one page of breeders and the total number of matches
+func (r *SQLRepository) AllBreeders(ctx context.Context, opts list.Options) ([]*Breeder, int, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	where := breederWhere(opts.Filters)
+
+	var total int
+	countQuery := r.Dialect.Rebind(`SELECT COUNT(*) FROM breeders` + where.SQL())
+	if err := r.DB.QueryRowContext(ctx, countQuery, where.Args()...).Scan(&total); err != nil {
+		return nil, 0, apperr.FromSQL(err, "breeder")
+	}
+
+	query := r.Dialect.Rebind(`SELECT id, breeder_name, address, city, prov_state,
+			country, zip, phone, email, active
+			FROM breeders` + where.SQL() +
+		list.OrderBy(opts, breederSortColumns, "breeder_name") + ` LIMIT ? OFFSET ?`)
+
+	rows, err := r.DB.QueryContext(ctx, query, append(where.Args(), opts.Limit(), opts.Offset())...)
+	if err != nil {
+		return nil, 0, apperr.FromSQL(err, "breeder")
+	}
+	defer rows.Close()
+
+	var breeders []*Breeder
+	for rows.Next() {
+		var b Breeder
+		err := rows.Scan(
+			&b.ID, &b.BreederName, &b.Address, &b.City,
+			&b.ProvState, &b.Country, &b.Zip, &b.Phone,
+			&b.Email, &b.Active,
+		)
+		if err != nil {
+			return nil, 0, apperr.FromSQL(err, "breeder")
+		}
+		breeders = append(breeders, &b)
+	}
+
+	return breeders, total, apperr.FromSQL(rows.Err(), "breeder")
+}
+
+// GetBreederByID returns a single breeder by ID
+func (r *SQLRepository) GetBreederByID(ctx context.Context, id int) (*Breeder, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := r.Dialect.Rebind(`SELECT id, breeder_name, address, city, prov_state,
+			country, zip, phone, email, active
+			FROM breeders WHERE id = ?`)
+
+	var breeder Breeder
+	err := r.DB.QueryRowContext(ctx, query, id).Scan(
+		&breeder.ID, &breeder.BreederName, &breeder.Address, &breeder.City,
+		&breeder.ProvState, &breeder.Country, &breeder.Zip, &breeder.Phone,
+		&breeder.Email, &breeder.Active,
+	)
+	if err != nil {
+		return nil, apperr.FromSQL(err, "breeder")
+	}
+
+	return &breeder, nil
+}
+
+// InsertBreeder inserts a new breeder and returns the ID
+func (r *SQLRepository) InsertBreeder(ctx context.Context, breeder *Breeder) (int, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `INSERT INTO breeders (breeder_name, address, city, prov_state,
+			country, zip, phone, email, active)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+
+	id, err := r.Dialect.Insert(ctx, r.DB, query,
+		breeder.BreederName, breeder.Address, breeder.City, breeder.ProvState,
+		breeder.Country, breeder.Zip, breeder.Phone, breeder.Email, breeder.Active,
+	)
+	if err != nil {
+		return 0, apperr.FromSQL(err, "breeder")
+	}
+
+	return id, nil
+}
+
+// UpdateBreeder updates an existing breeder, or returns NotFound
+func (r *SQLRepository) UpdateBreeder(ctx context.Context, breeder *Breeder) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `UPDATE breeders SET breeder_name = ?, address = ?, city = ?,
+			prov_state = ?, country = ?, zip = ?, phone = ?, email = ?,
+			active = ? WHERE id = ?`
+
+	result, err := r.DB.ExecContext(ctx, r.Dialect.Rebind(query),
+		breeder.BreederName, breeder.Address, breeder.City, breeder.ProvState,
+		breeder.Country, breeder.Zip, breeder.Phone, breeder.Email,
+		breeder.Active, breeder.ID,
+	)
+
+	return apperr.FromResult(result, err, "breeder")
+}
+
+// DeleteBreeder deletes a breeder by ID, or returns NotFound
+func (r *SQLRepository) DeleteBreeder(ctx context.Context, id int) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := r.Dialect.Rebind(`DELETE FROM breeders WHERE id = ?`)
+	result, err := r.DB.ExecContext(ctx, query, id)
+	return apperr.FromResult(result, err, "breeder")
+}
+
+// breederSortColumns maps BreederSortFields to SQL columns
+var breederSortColumns = map[string]string{
+	"id":           "id",
+	"breeder_name": "breeder_name",
+	"city":         "city",
+	"prov_state":   "prov_state",
+	"country":      "country",
+}
+
+// breederWhere builds the WHERE clause for the breeder filters in f. Text filters compare
+// lowercased, since only MySQL's collation ignores case.
+func breederWhere(f list.Filters) *list.Where {
+	var where list.Where
+	if f.City != "" {
+		where.Add("LOWER(city) = LOWER(?)", f.City)
+	}
+	if f.ProvState != "" {
+		where.Add("LOWER(prov_state) = LOWER(?)", f.ProvState)
+	}
+	if f.Country != "" {
+		where.Add("LOWER(country) = LOWER(?)", f.Country)
+	}
+	if f.Active != nil {
+		where.Add("active = ?", *f.Active)
+	}
+	return &where
+}
@@ -0,0 +1,21 @@
+package breeder
+
+import "go-breeders/internal/validate"
+
+// validateBreeder checks breeder against the field rules
+func validateBreeder(breeder *Breeder) error {
+	v := validate.New()
+
+	v.Required("breeder_name", breeder.BreederName)
+	v.MaxLength("breeder_name", breeder.BreederName, 255)
+	v.MaxLength("address", breeder.Address, 255)
+	v.MaxLength("city", breeder.City, 255)
+	v.MaxLength("prov_state", breeder.ProvState, 255)
+	v.MaxLength("country", breeder.Country, 255)
+	v.MaxLength("zip", breeder.Zip, 20)
+	v.Phone("phone", breeder.Phone)
+	v.Email("email", breeder.Email)
+	v.OneOf("active", breeder.Active, 0, 1)
+
+	return v.Err()
+}
@@ -0,0 +1,124 @@
+// Package cache is a small in-process cache for reference data such as breeds.
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Stats are the counters of a Cache, reported by /debug/cache
+type Stats struct {
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Evictions uint64 `json:"evictions"` // entries dropped to stay within the size bound
+	Entries   int    `json:"entries"`
+}
+
+// Add returns the sum of s and o
+func (s Stats) Add(o Stats) Stats {
+	s.Hits += o.Hits
+	s.Misses += o.Misses
+	s.Evictions += o.Evictions
+	s.Entries += o.Entries
+	return s
+}
+
+// entry is a cached value and when it expires
+type entry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time
+}
+
+// Cache is a thread-safe least-recently-used cache whose entries expire after a TTL
+type Cache[K comparable, V any] struct {
+	mu    sync.Mutex
+	ttl   time.Duration
+	size  int
+	items map[K]*list.Element
+	lru   *list.List // front is most recently used
+	stats Stats
+	now   func() time.Time
+}
+
+// New creates a cache holding at most size entries for ttl each
+func New[K comparable, V any](ttl time.Duration, size int) *Cache[K, V] {
+	return &Cache[K, V]{
+		ttl:   ttl,
+		size:  max(size, 1),
+		items: make(map[K]*list.Element),
+		lru:   list.New(),
+		now:   time.Now,
+	}
+}
+
+// Get returns the live value for key and counts a hit or a miss
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		e := el.Value.(*entry[K, V])
+		if c.now().Before(e.expires) {
+			c.lru.MoveToFront(el)
+			c.stats.Hits++
+			return e.value, true
+		}
+		c.remove(el)
+	}
+
+	c.stats.Misses++
+	var zero V
+	return zero, false
+}
+
+// Set stores value for key, evicting the least recently used entry when full
+func (c *Cache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expires := c.now().Add(c.ttl)
+	if el, ok := c.items[key]; ok {
+		el.Value = &entry[K, V]{key: key, value: value, expires: expires}
+		c.lru.MoveToFront(el)
+		return
+	}
+
+	c.items[key] = c.lru.PushFront(&entry[K, V]{key: key, value: value, expires: expires})
+	for c.lru.Len() > c.size {
+		c.remove(c.lru.Back())
+		c.stats.Evictions++
+	}
+}
+
+// Load returns the cached value for key, or calls load and caches its
+// result. Errors are returned but not cached.
+func (c *Cache[K, V]) Load(key K, load func() (V, error)) (V, error) {
+	if v, ok := c.Get(key); ok {
+		return v, nil
+	}
+
+	v, err := load()
+	if err != nil {
+		return v, err
+	}
+	c.Set(key, v)
+	return v, nil
+}
+
+// Stats returns the current counters
+func (c *Cache[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.Entries = c.lru.Len()
+	return stats
+}
+
+// remove drops el; the caller must hold c.mu
+func (c *Cache[K, V]) remove(el *list.Element) {
+	c.lru.Remove(el)
+	delete(c.items, el.Value.(*entry[K, V]).key)
+}
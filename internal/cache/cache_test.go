@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	clock := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := New[string, int](time.Minute, 2)
+	c.now = func() time.Time { return clock }
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("expected an empty cache to miss")
+	}
+	c.Set("a", 1)
+	c.Set("b", 2)
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Fatalf("expected a=1, got %v %v", v, ok)
+	}
+
+	// b is now the least recently used, so adding c evicts it
+	c.Set("c", 3)
+	if _, ok := c.Get("b"); ok {
+		t.Error("expected b to be evicted")
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Error("expected a to survive the eviction")
+	}
+
+	clock = clock.Add(time.Minute)
+	if _, ok := c.Get("a"); ok {
+		t.Error("expected a to expire after the TTL")
+	}
+
+	stats := c.Stats()
+	want := Stats{Hits: 2, Misses: 3, Evictions: 1, Entries: 1}
+	if stats != want {
+		t.Errorf("got stats %+v, want %+v", stats, want)
+	}
+}
+
+func TestCache_Load(t *testing.T) {
+	c := New[int, string](time.Minute, 10)
+	calls := 0
+	load := func() (string, error) {
+		calls++
+		return "value", nil
+	}
+
+	for range 3 {
+		if v, err := c.Load(1, load); err != nil || v != "value" {
+			t.Fatalf("got %q, %v", v, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected one load, got %d", calls)
+	}
+
+	boom := errors.New("boom")
+	for range 2 {
+		if _, err := c.Load(2, func() (string, error) { return "", boom }); !errors.Is(err, boom) {
+			t.Fatalf("expected the load error, got %v", err)
+		}
+	}
+	if stats := c.Stats(); stats.Entries != 1 {
+		t.Errorf("expected errors not to be cached, got %d entries", stats.Entries)
+	}
+}
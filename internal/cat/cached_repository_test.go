@@ -0,0 +1,54 @@
+package cat
+
+import (
+	"context"
+	"go-breeders/internal/breeder"
+	"go-breeders/internal/list"
+	"testing"
+	"time"
+)
+
+// The caching itself is shared with dog.CachedRepository and tested there;
+// this covers the cat side: breed copies and cats that are never cached
+
+func TestCachedRepository_BreedCopies(t *testing.T) {
+	ctx := context.Background()
+	repo := NewCachedRepository(NewMockRepository(), time.Minute, 10)
+
+	first, err := repo.GetBreedByID(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first.Breed = "Changed by the caller"
+
+	second, err := repo.GetBreedByID(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Breed == "Changed by the caller" {
+		t.Error("expected the cache to hand out copies")
+	}
+	if stats := repo.BreedCacheStats(); stats.Hits != 1 {
+		t.Errorf("expected the second lookup to hit the cache, got %+v", stats)
+	}
+}
+
+func TestCachedRepository_CatsPassThrough(t *testing.T) {
+	ctx := context.Background()
+	breeders := breeder.NewMemoryRepository(breeder.MockFixtures())
+	repo := NewCachedRepository(NewMemoryRepository(breeders, MockFixtures()), time.Minute, 10)
+
+	_, before, err := repo.AllCats(ctx, list.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.InsertCat(ctx, &Cat{CatName: "Tom", BreedID: 1, Weight: 10, DateOfBirth: time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)}); err != nil {
+		t.Fatal(err)
+	}
+	if _, after, err := repo.AllCats(ctx, list.Options{}); err != nil || after != before+1 {
+		t.Errorf("expected %d cats after an insert, got %d (%v)", before+1, after, err)
+	}
+	if stats := repo.BreedCacheStats(); stats.Hits+stats.Misses != 0 {
+		t.Errorf("expected cat reads to bypass the breed cache, got %+v", stats)
+	}
+}
@@ -0,0 +1,412 @@
+// Package cattest is a conformance suite for cat.Repository implementations,
+// so the SQL, in-memory and mock repositories are held to the same behaviour.
+package cattest
+
+import (
+	"context"
+	"go-breeders/internal/apperr"
+	"go-breeders/internal/cat"
+	"go-breeders/internal/list"
+	"strings"
+	"testing"
+	"time"
+	"unicode"
+)
+
+// missingID is an ID no repository is expected to hold
+const missingID = 987654321
+
+// Subject is a repository under test
+type Subject struct {
+	Repo cat.Repository
+	// BreederID is an existing breeder for the foreign-key checks;
+	// zero means the repository does not check breeder IDs
+	BreederID int
+	// DeleteBreeder deletes a breeder from the store Repo reads breeders
+	// from; nil skips the ON DELETE SET NULL check
+	DeleteBreeder func(ctx context.Context, id int) error
+}
+
+// Factory returns a fresh Subject for each subtest. The repository must
+// hold at least two breeds; every backend is seeded with them.
+type Factory func(t *testing.T) Subject
+
+// RunReadContract checks ordering, paging, filtering and not-found
+// behaviour. It suits fakes such as cat.MockRepository that drop writes.
+func RunReadContract(t *testing.T, factory Factory) {
+	ctx := context.Background()
+
+	t.Run("breeds sorted by name by default", func(t *testing.T) {
+		breeds := allBreeds(t, factory(t).Repo, list.Options{})
+		for i := 1; i < len(breeds); i++ {
+			if strings.ToLower(breeds[i-1].Breed) > strings.ToLower(breeds[i].Breed) {
+				t.Fatalf("%q sorted before %q", breeds[i-1].Breed, breeds[i].Breed)
+			}
+		}
+	})
+
+	t.Run("breeds sorted descending with rounded average weight", func(t *testing.T) {
+		breeds := allBreeds(t, factory(t).Repo, list.Options{Sort: "average_weight", Desc: true})
+		for i, b := range breeds {
+			if want := (b.WeightLowLbs + b.WeightHighLbs + 1) / 2; b.AverageWeight != want {
+				t.Errorf("%s: average weight %d, want %d", b.Breed, b.AverageWeight, want)
+			}
+			if i > 0 && breeds[i-1].AverageWeight < b.AverageWeight {
+				t.Fatalf("%d sorted before %d", breeds[i-1].AverageWeight, b.AverageWeight)
+			}
+		}
+	})
+
+	t.Run("breeds paged", func(t *testing.T) {
+		repo := factory(t).Repo
+		first, total, err := repo.AllBreeds(ctx, list.Options{Page: 1, PerPage: 2})
+		if err != nil {
+			t.Fatal(err)
+		}
+		second, total2, err := repo.AllBreeds(ctx, list.Options{Page: 2, PerPage: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(first) != 2 || len(second) != 1 {
+			t.Fatalf("expected pages of 2 and 1, got %d and %d", len(first), len(second))
+		}
+		if second[0].ID != first[1].ID {
+			t.Errorf("page 2 of size 1 returned breed %d, want %d", second[0].ID, first[1].ID)
+		}
+		if total != total2 || total < 2 {
+			t.Errorf("totals differ between pages: %d and %d", total, total2)
+		}
+	})
+
+	t.Run("breeds filtered", func(t *testing.T) {
+		repo := factory(t).Repo
+		all := allBreeds(t, repo, list.Options{})
+		lifespan := all[0].Lifespan
+
+		filtered, _, err := repo.AllBreeds(ctx, list.Options{Page: 1, PerPage: list.MaxPerPage, Filters: list.Filters{MinLifespan: lifespan}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		found := false
+		for _, b := range filtered {
+			if b.Lifespan < lifespan {
+				t.Errorf("%s has lifespan %d, below the minimum %d", b.Breed, b.Lifespan, lifespan)
+			}
+			found = found || b.ID == all[0].ID
+		}
+		if !found {
+			t.Errorf("breed %d matches the filter but was not returned", all[0].ID)
+		}
+	})
+
+	t.Run("breeds filtered by origin ignoring case", func(t *testing.T) {
+		repo := factory(t).Repo
+		var want *cat.Breed
+		for _, b := range allBreeds(t, repo, list.Options{}) {
+			if b.GeographicOrigin != "" {
+				want = b
+				break
+			}
+		}
+		if want == nil {
+			t.Skip("no breed has an origin")
+		}
+
+		origin := swapCase(want.GeographicOrigin)
+		filtered, _, err := repo.AllBreeds(ctx, list.Options{Page: 1, PerPage: list.MaxPerPage, Filters: list.Filters{Origin: origin}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		found := false
+		for _, b := range filtered {
+			if !strings.EqualFold(b.GeographicOrigin, origin) {
+				t.Errorf("%s comes from %q, filtered on %q", b.Breed, b.GeographicOrigin, origin)
+			}
+			found = found || b.ID == want.ID
+		}
+		if !found {
+			t.Errorf("%s from %q was not returned for origin %q", want.Breed, want.GeographicOrigin, origin)
+		}
+	})
+
+	t.Run("breed not found", func(t *testing.T) {
+		_, err := factory(t).Repo.GetBreedByID(ctx, missingID)
+		expectKind(t, err, apperr.NotFound)
+	})
+
+	t.Run("cat found by ID", func(t *testing.T) {
+		repo := factory(t).Repo
+		cats, _, err := repo.AllCats(ctx, list.Options{PerPage: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(cats) == 0 {
+			t.Skip("no cats to look up")
+		}
+		c, err := repo.GetCatByID(ctx, cats[0].ID, list.Expand{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.ID != cats[0].ID || c.CatName != cats[0].CatName {
+			t.Errorf("got cat %d %q, want %d %q", c.ID, c.CatName, cats[0].ID, cats[0].CatName)
+		}
+	})
+
+	t.Run("cat not found", func(t *testing.T) {
+		_, err := factory(t).Repo.GetCatByID(ctx, missingID, list.Expand{Breed: true, Breeder: true})
+		expectKind(t, err, apperr.NotFound)
+	})
+
+	t.Run("update missing cat", func(t *testing.T) {
+		err := factory(t).Repo.UpdateCat(ctx, &cat.Cat{ID: missingID, CatName: "Ghost", DateOfBirth: birthday})
+		expectKind(t, err, apperr.NotFound)
+	})
+
+	t.Run("delete missing cat", func(t *testing.T) {
+		expectKind(t, factory(t).Repo.DeleteCat(ctx, missingID), apperr.NotFound)
+	})
+}
+
+// RunRepositoryContract runs RunReadContract plus insert, update and
+// delete round trips and foreign-key checks
+func RunRepositoryContract(t *testing.T, factory Factory) {
+	ctx := context.Background()
+
+	RunReadContract(t, factory)
+
+	t.Run("insert round trip", func(t *testing.T) {
+		s := factory(t)
+		breed := allBreeds(t, s.Repo, list.Options{})[0]
+		want := newCat("Tom", breed.ID, s.BreederID)
+
+		id := insert(t, s.Repo, want)
+		got, err := s.Repo.GetCatByID(ctx, id, list.Expand{Breed: true, Breeder: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want.ID = id
+		expectSame(t, got, want)
+		if got.Breed == nil || got.Breed.ID != breed.ID {
+			t.Errorf("expected breed %d to be expanded, got %+v", breed.ID, got.Breed)
+		}
+		if s.BreederID != 0 && (got.Breeder == nil || got.Breeder.ID != s.BreederID) {
+			t.Errorf("expected breeder %d to be expanded, got %+v", s.BreederID, got.Breeder)
+		}
+	})
+
+	t.Run("insert assigns new IDs", func(t *testing.T) {
+		s := factory(t)
+		breed := allBreeds(t, s.Repo, list.Options{})[0]
+		first := insert(t, s.Repo, newCat("One", breed.ID, 0))
+		second := insert(t, s.Repo, newCat("Two", breed.ID, 0))
+		if first <= 0 || second <= 0 || first == second {
+			t.Errorf("expected distinct positive IDs, got %d and %d", first, second)
+		}
+	})
+
+	t.Run("update round trip", func(t *testing.T) {
+		s := factory(t)
+		breeds := allBreeds(t, s.Repo, list.Options{})
+		id := insert(t, s.Repo, newCat("Tom", breeds[0].ID, 0))
+
+		want := newCat("Felix", breeds[1].ID, s.BreederID)
+		want.ID = id
+		want.Weight = 42
+		want.SpayedOrNeutered = 1
+		if err := s.Repo.UpdateCat(ctx, want); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := s.Repo.GetCatByID(ctx, id, list.Expand{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expectSame(t, got, want)
+
+		// an update that changes nothing still finds the row
+		if err := s.Repo.UpdateCat(ctx, want); err != nil {
+			t.Errorf("unchanged update: %v", err)
+		}
+	})
+
+	t.Run("delete removes the cat", func(t *testing.T) {
+		s := factory(t)
+		breed := allBreeds(t, s.Repo, list.Options{})[0]
+		id := insert(t, s.Repo, newCat("Tom", breed.ID, 0))
+
+		if err := s.Repo.DeleteCat(ctx, id); err != nil {
+			t.Fatal(err)
+		}
+		_, err := s.Repo.GetCatByID(ctx, id, list.Expand{})
+		expectKind(t, err, apperr.NotFound)
+		expectKind(t, s.Repo.DeleteCat(ctx, id), apperr.NotFound)
+	})
+
+	t.Run("zero references are stored as none", func(t *testing.T) {
+		s := factory(t)
+		id := insert(t, s.Repo, newCat("Stray", 0, 0))
+
+		got, err := s.Repo.GetCatByID(ctx, id, list.Expand{Breed: true, Breeder: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.BreedID != 0 || got.BreederID != 0 || got.Breed != nil || got.Breeder != nil {
+			t.Errorf("expected no breed or breeder, got %+v", got)
+		}
+	})
+
+	t.Run("missing references rejected", func(t *testing.T) {
+		s := factory(t)
+		breed := allBreeds(t, s.Repo, list.Options{})[0]
+
+		_, err := s.Repo.InsertCat(ctx, newCat("Tom", missingID, 0))
+		expectKind(t, err, apperr.Validation)
+
+		if s.BreederID != 0 {
+			_, err = s.Repo.InsertCat(ctx, newCat("Tom", breed.ID, missingID))
+			expectKind(t, err, apperr.Validation)
+		}
+
+		id := insert(t, s.Repo, newCat("Tom", breed.ID, 0))
+		c := newCat("Tom", missingID, 0)
+		c.ID = id
+		expectKind(t, s.Repo.UpdateCat(ctx, c), apperr.Validation)
+	})
+
+	t.Run("deleting the breeder clears it from its cats", func(t *testing.T) {
+		s := factory(t)
+		if s.DeleteBreeder == nil || s.BreederID == 0 {
+			t.Skip("repository does not check breeders")
+		}
+		breed := allBreeds(t, s.Repo, list.Options{})[0]
+		id := insert(t, s.Repo, newCat("Rex", breed.ID, s.BreederID))
+
+		if err := s.DeleteBreeder(ctx, s.BreederID); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := s.Repo.GetCatByID(ctx, id, list.Expand{Breeder: true})
+		if err != nil {
+			t.Fatalf("cat should outlive its breeder: %v", err)
+		}
+		if got.BreederID != 0 || got.Breeder != nil {
+			t.Errorf("expected no breeder, got breeder_id %d and %+v", got.BreederID, got.Breeder)
+		}
+		cats, _, err := s.Repo.AllCats(ctx, list.Options{Page: 1, PerPage: list.MaxPerPage, Filters: list.Filters{BreederID: s.BreederID}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(cats) != 0 {
+			t.Errorf("expected no cats for the deleted breeder, got %d", len(cats))
+		}
+	})
+
+	t.Run("cats filtered and sorted", func(t *testing.T) {
+		s := factory(t)
+		breed := allBreeds(t, s.Repo, list.Options{})[0]
+		for i, name := range []string{"Zoe", "Ada", "Milo"} {
+			c := newCat(name, breed.ID, 0)
+			c.Weight = 10 + i
+			insert(t, s.Repo, c)
+		}
+
+		filters := list.Filters{BreedID: breed.ID}
+		cats, total, err := s.Repo.AllCats(ctx, list.Options{Page: 1, PerPage: list.MaxPerPage, Sort: "cat_name", Filters: filters})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total < 3 || len(cats) < 3 {
+			t.Fatalf("expected at least the 3 inserted cats, got %d of %d", len(cats), total)
+		}
+		for i, c := range cats {
+			if c.BreedID != breed.ID {
+				t.Errorf("cat %d has breed %d, filtered on %d", c.ID, c.BreedID, breed.ID)
+			}
+			if i > 0 && strings.ToLower(cats[i-1].CatName) > strings.ToLower(c.CatName) {
+				t.Errorf("%q sorted before %q", cats[i-1].CatName, c.CatName)
+			}
+		}
+
+		cats, _, err = s.Repo.AllCats(ctx, list.Options{Page: 1, PerPage: list.MaxPerPage, Sort: "weight", Desc: true, Filters: filters})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 1; i < len(cats); i++ {
+			if cats[i-1].Weight < cats[i].Weight {
+				t.Errorf("weight %d sorted before %d", cats[i-1].Weight, cats[i].Weight)
+			}
+		}
+	})
+}
+
+// birthday is the date of birth given to test cats
+var birthday = time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+
+func newCat(name string, breedID, breederID int) *cat.Cat {
+	return &cat.Cat{
+		CatName:     name,
+		BreedID:     breedID,
+		BreederID:   breederID,
+		Color:       "brown",
+		DateOfBirth: birthday,
+		Description: "contract test cat",
+		Weight:      20,
+	}
+}
+
+// insert adds c and deletes it again when the test ends, since SQL
+// backends may be shared between runs
+func insert(t *testing.T, repo cat.Repository, c *cat.Cat) int {
+	t.Helper()
+	id, err := repo.InsertCat(context.Background(), c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { repo.DeleteCat(context.Background(), id) })
+	return id
+}
+
+func allBreeds(t *testing.T, repo cat.Repository, opts list.Options) []*cat.Breed {
+	t.Helper()
+	opts.Page, opts.PerPage = 1, list.MaxPerPage
+	breeds, _, err := repo.AllBreeds(context.Background(), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(breeds) < 2 {
+		t.Fatalf("expected at least 2 breeds, got %d", len(breeds))
+	}
+	return breeds
+}
+
+func expectKind(t *testing.T, err error, kind apperr.Kind) {
+	t.Helper()
+	if !apperr.Is(err, kind) {
+		t.Errorf("expected a %v error, got %v", kind, err)
+	}
+}
+
+func expectSame(t *testing.T, got, want *cat.Cat) {
+	t.Helper()
+	if got.ID != want.ID || got.CatName != want.CatName || got.BreedID != want.BreedID ||
+		got.BreederID != want.BreederID || got.Color != want.Color ||
+		got.SpayedOrNeutered != want.SpayedOrNeutered || got.Description != want.Description ||
+		got.Weight != want.Weight {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if got.DateOfBirth.Format(time.DateOnly) != want.DateOfBirth.Format(time.DateOnly) {
+		t.Errorf("date of birth %v, want %v", got.DateOfBirth, want.DateOfBirth)
+	}
+}
+
+// swapCase flips the case of every letter in s, so "Thailand" becomes
+// "tHAILAND", for checking that text filters ignore case
+func swapCase(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsUpper(r) {
+			return unicode.ToLower(r)
+		}
+		return unicode.ToUpper(r)
+	}, s)
+}
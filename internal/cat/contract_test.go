@@ -0,0 +1,58 @@
+package cat_test
+
+import (
+	"context"
+	"go-breeders/internal/breeder"
+	"go-breeders/internal/cat"
+	"go-breeders/internal/cat/cattest"
+	"go-breeders/internal/database/databasetest"
+	"testing"
+)
+
+func TestMockRepository_Contract(t *testing.T) {
+	cattest.RunReadContract(t, func(t *testing.T) cattest.Subject {
+		return cattest.Subject{Repo: cat.NewMockRepository()}
+	})
+}
+
+func TestMemoryRepository_Contract(t *testing.T) {
+	cattest.RunRepositoryContract(t, func(t *testing.T) cattest.Subject {
+		breeders := breeder.NewMemoryRepository(breeder.MockFixtures())
+		return cattest.Subject{Repo: cat.NewMemoryRepository(breeders, cat.MockFixtures()), BreederID: 1, DeleteBreeder: breeders.DeleteBreeder}
+	})
+}
+
+func TestSQLiteRepository_Contract(t *testing.T) {
+	cattest.RunRepositoryContract(t, func(t *testing.T) cattest.Subject {
+		db := databasetest.SQLite(t)
+		return sqlSubject(t, cat.NewSQLiteRepository(db, 0), breeder.NewSQLiteRepository(db, 0))
+	})
+}
+
+func TestMySQLRepository_Contract(t *testing.T) {
+	cattest.RunRepositoryContract(t, func(t *testing.T) cattest.Subject {
+		db := databasetest.MySQL(t)
+		return sqlSubject(t, cat.NewMySQLRepository(db, 0), breeder.NewMySQLRepository(db, 0))
+	})
+}
+
+func TestPostgresRepository_Contract(t *testing.T) {
+	cattest.RunRepositoryContract(t, func(t *testing.T) cattest.Subject {
+		db := databasetest.Postgres(t)
+		return sqlSubject(t, cat.NewPostgresRepository(db, 0), breeder.NewPostgresRepository(db, 0))
+	})
+}
+
+// sqlSubject adds a breeder for the foreign-key checks and removes it afterwards
+func sqlSubject(t *testing.T, repo cat.Repository, breeders breeder.Repository) cattest.Subject {
+	t.Helper()
+	ctx := context.Background()
+
+	id, err := breeders.InsertBreeder(ctx, &breeder.Breeder{BreederName: "Contract Kennels", Active: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { breeders.DeleteBreeder(ctx, id) })
+
+	return cattest.Subject{Repo: repo, BreederID: id, DeleteBreeder: breeders.DeleteBreeder}
+}
@@ -1,11 +1,19 @@
 package cat
 
 import (
+	"fmt"
+	"go-breeders/internal/apperr"
+	"go-breeders/internal/list"
 	"net/http"
+	"strconv"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/tsawler/toolbox"
 )
 
+// maxJSONSize caps the request body accepted by the write endpoints (1 MB)
+const maxJSONSize = 1 << 20
+
 // Handler handles HTTP requests for cat domain
 type Handler struct {
 	service *Service
@@ -16,28 +24,191 @@ func NewHandler(service *Service) *Handler {
 	return &Handler{service: service}
 }
 
-// GetAllBreedsJSON returns all cat breeds as JSON
+// GetAllBreedsJSON returns one page of cat breeds as JSON.
+// Supports ?page=, ?per_page=, ?sort= (prefix with - for descending) and filters.
 func (h *Handler) GetAllBreedsJSON(w http.ResponseWriter, r *http.Request) {
 	var t toolbox.Tools
 
-	breeds, err := h.service.GetAllBreeds()
+	opts, err := list.Parse(r, BreedSortFields)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	breeds, total, err := h.service.GetAllBreeds(r.Context(), opts)
 	if err != nil {
-		_ = t.ErrorJSON(w, err, http.StatusInternalServerError)
+		apperr.WriteJSON(w, err)
 		return
 	}
 
-	_ = t.WriteJSON(w, http.StatusOK, breeds)
+	_ = t.WriteJSON(w, http.StatusOK, list.NewPage(breeds, total, opts))
 }
 
-// GetAllCatsJSON returns all cats as JSON
+// GetBreedByIDJSON returns the cat breed identified by the {id} URL param as JSON
+func (h *Handler) GetBreedByIDJSON(w http.ResponseWriter, r *http.Request) {
+	var t toolbox.Tools
+
+	id, err := idParam(r)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	breed, err := h.service.GetBreedByID(r.Context(), id)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	_ = t.WriteJSON(w, http.StatusOK, breed)
+}
+
+// GetAllCatsJSON returns one page of cats as JSON.
+// Supports ?page=, ?per_page=, ?sort= (prefix with - for descending), filters
+// and ?expand=breed,breeder.
 func (h *Handler) GetAllCatsJSON(w http.ResponseWriter, r *http.Request) {
 	var t toolbox.Tools
 
-	cats, err := h.service.GetAllCats()
+	opts, err := list.Parse(r, CatSortFields)
 	if err != nil {
-		_ = t.ErrorJSON(w, err, http.StatusInternalServerError)
+		apperr.WriteJSON(w, err)
 		return
 	}
 
-	_ = t.WriteJSON(w, http.StatusOK, cats)
+	cats, total, err := h.service.GetAllCats(r.Context(), opts)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	_ = t.WriteJSON(w, http.StatusOK, list.NewPage(cats, total, opts))
+}
+
+// GetCatByIDJSON returns the cat identified by the {id} URL param as JSON.
+// Supports ?expand=breed,breeder.
+func (h *Handler) GetCatByIDJSON(w http.ResponseWriter, r *http.Request) {
+	var t toolbox.Tools
+
+	id, err := idParam(r)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	expand, err := list.ParseExpand(r)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	cat, err := h.service.GetCatByID(r.Context(), id, expand)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	_ = t.WriteJSON(w, http.StatusOK, cat)
+}
+
+// CreateCatJSON creates a cat from the JSON body and returns it with a Location header
+func (h *Handler) CreateCatJSON(w http.ResponseWriter, r *http.Request) {
+	t := toolbox.Tools{MaxJSONSize: maxJSONSize}
+
+	var cat Cat
+	if err := t.ReadJSON(w, r, &cat); err != nil {
+		apperr.WriteJSON(w, apperr.New(apperr.BadRequest, err.Error()))
+		return
+	}
+
+	id, err := h.service.CreateCat(r.Context(), &cat)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+	cat.ID = id
+
+	headers := make(http.Header)
+	headers.Set("Location", fmt.Sprintf("/api/cats/%d", id))
+	_ = t.WriteJSON(w, http.StatusCreated, cat, headers)
+}
+
+// UpdateCatJSON replaces the cat identified by the {id} URL param with the JSON body
+func (h *Handler) UpdateCatJSON(w http.ResponseWriter, r *http.Request) {
+	t := toolbox.Tools{MaxJSONSize: maxJSONSize}
+
+	id, err := idParam(r)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	var cat Cat
+	if err := t.ReadJSON(w, r, &cat); err != nil {
+		apperr.WriteJSON(w, apperr.New(apperr.BadRequest, err.Error()))
+		return
+	}
+	cat.ID = id
+
+	if err := h.service.UpdateCat(r.Context(), &cat); err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	_ = t.WriteJSON(w, http.StatusOK, cat)
+}
+
+// PatchCatJSON applies the fields present in the JSON body to an existing cat
+func (h *Handler) PatchCatJSON(w http.ResponseWriter, r *http.Request) {
+	t := toolbox.Tools{MaxJSONSize: maxJSONSize}
+
+	id, err := idParam(r)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	cat, err := h.service.GetCatByID(r.Context(), id, list.Expand{})
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	// decoding onto the stored cat leaves fields absent from the body untouched
+	if err := t.ReadJSON(w, r, cat); err != nil {
+		apperr.WriteJSON(w, apperr.New(apperr.BadRequest, err.Error()))
+		return
+	}
+	cat.ID = id
+
+	if err := h.service.UpdateCat(r.Context(), cat); err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	_ = t.WriteJSON(w, http.StatusOK, cat)
+}
+
+// DeleteCatJSON deletes the cat identified by the {id} URL param
+func (h *Handler) DeleteCatJSON(w http.ResponseWriter, r *http.Request) {
+	id, err := idParam(r)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	if err := h.service.DeleteCat(r.Context(), id); err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// idParam parses the {id} URL param as a positive integer
+func idParam(r *http.Request) (int, error) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id < 1 {
+		return 0, apperr.New(apperr.BadRequest, "invalid id")
+	}
+	return id, nil
 }
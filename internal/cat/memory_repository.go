@@ -0,0 +1,241 @@
+package cat
+
+import (
+	"context"
+	"go-breeders/internal/apperr"
+	"go-breeders/internal/list"
+	"maps"
+	"sync"
+)
+
+// Fixtures is the initial data for a MemoryRepository
+type Fixtures struct {
+	Breeds []*Breed
+	Cats   []*Cat
+}
+
+// MemoryRepository is a thread-safe in-memory implementation of Repository.
+// It stores copies, so callers may modify what they pass in or get back.
+type MemoryRepository struct {
+	mu       sync.RWMutex
+	breeds   map[int]*Breed
+	cats     map[int]*Cat
+	nextID   int
+	breeders BreederFinder // resolves breeder_id; nil skips the check
+}
+
+// NewMemoryRepository creates an in-memory repository for cats seeded with
+// fixtures. breeders is used to check breeder IDs and expand breeders.
+// When breeders reports deletions, as breeder.MemoryRepository does, cats of a
+// deleted breeder lose their breeder_id like the SQL schema's ON DELETE SET NULL.
+func NewMemoryRepository(breeders BreederFinder, fixtures Fixtures) Repository {
+	r := &MemoryRepository{
+		breeds:   make(map[int]*Breed),
+		cats:     make(map[int]*Cat),
+		nextID:   1,
+		breeders: breeders,
+	}
+	for _, b := range fixtures.Breeds {
+		breed := *b
+		r.breeds[b.ID] = &breed
+	}
+	for _, d := range fixtures.Cats {
+		cat := *d
+		cat.Breed, cat.Breeder = nil, nil
+		r.cats[d.ID] = &cat
+		r.nextID = max(r.nextID, d.ID+1)
+	}
+	if d, ok := breeders.(breederDeletions); ok {
+		d.OnDelete(r.clearBreeder)
+	}
+	return r
+}
+
+// AllBreeds returns one page of cat breeds and the total number of matches
+func (r *MemoryRepository) AllBreeds(ctx context.Context, opts list.Options) ([]*Breed, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	breeds, total := list.Apply(list.ByID(r.breeds), opts, keepBreed(opts.Filters), breedSortKey, "breed")
+	for i, b := range breeds {
+		breed := *b
+		breeds[i] = &breed
+	}
+	return breeds, total, nil
+}
+
+// GetBreedByID returns a single cat breed by ID
+func (r *MemoryRepository) GetBreedByID(ctx context.Context, id int) (*Breed, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	b, ok := r.breeds[id]
+	if !ok {
+		return nil, apperr.NotFoundf("cat breed %d not found", id)
+	}
+	breed := *b
+	return &breed, nil
+}
+
+// AllCats returns one page of cats and the total number of matches
+func (r *MemoryRepository) AllCats(ctx context.Context, opts list.Options) ([]*Cat, int, error) {
+	r.mu.RLock()
+	cats, total := list.Apply(list.ByID(r.cats), opts, keepCat(opts.Filters), catSortKey, "cat_name")
+	for i, d := range cats {
+		cats[i] = r.copyCat(d, opts.Expand)
+	}
+	r.mu.RUnlock()
+
+	for _, d := range cats {
+		r.expandBreeder(ctx, d, opts.Expand)
+	}
+	return cats, total, nil
+}
+
+// GetCatByID returns a single cat by ID with the related records in expand
+func (r *MemoryRepository) GetCatByID(ctx context.Context, id int, expand list.Expand) (*Cat, error) {
+	r.mu.RLock()
+	d, ok := r.cats[id]
+	if ok {
+		d = r.copyCat(d, expand)
+	}
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, apperr.NotFoundf("cat %d not found", id)
+	}
+	r.expandBreeder(ctx, d, expand)
+	return d, nil
+}
+
+// InsertCat stores a copy of cat under the next ID and returns the ID
+func (r *MemoryRepository) InsertCat(ctx context.Context, cat *Cat) (int, error) {
+	if err := r.checkBreeder(ctx, cat); err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkBreed(cat); err != nil {
+		return 0, err
+	}
+
+	d := *cat
+	d.ID, d.Breed, d.Breeder = r.nextID, nil, nil
+	r.cats[d.ID] = &d
+	r.nextID++
+
+	return d.ID, nil
+}
+
+// UpdateCat replaces an existing cat
+func (r *MemoryRepository) UpdateCat(ctx context.Context, cat *Cat) error {
+	if err := r.checkBreeder(ctx, cat); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.cats[cat.ID]; !ok {
+		return apperr.NotFoundf("cat %d not found", cat.ID)
+	}
+	if err := r.checkBreed(cat); err != nil {
+		return err
+	}
+
+	d := *cat
+	d.Breed, d.Breeder = nil, nil
+	r.cats[d.ID] = &d
+
+	return nil
+}
+
+// DeleteCat deletes a cat by ID
+func (r *MemoryRepository) DeleteCat(ctx context.Context, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.cats[id]; !ok {
+		return apperr.NotFoundf("cat %d not found", id)
+	}
+	delete(r.cats, id)
+
+	return nil
+}
+
+// Snapshot records the repository's contents and returns a function that puts
+// them back, so a unit of work can undo its writes
+func (r *MemoryRepository) Snapshot() (restore func()) {
+	r.mu.RLock()
+	breeds, cats, nextID := maps.Clone(r.breeds), maps.Clone(r.cats), r.nextID
+	r.mu.RUnlock()
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.breeds, r.cats, r.nextID = breeds, cats, nextID
+	}
+}
+
+// breederDeletions is implemented by breeder repositories that report deletions
+type breederDeletions interface {
+	OnDelete(fn func(id int))
+}
+
+// clearBreeder removes breeder id from every cat that references it. The
+// cats are replaced rather than changed in place so snapshots stay intact.
+func (r *MemoryRepository) clearBreeder(id int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, c := range r.cats {
+		if c.BreederID == id {
+			cat := *c
+			cat.BreederID = 0
+			r.cats[key] = &cat
+		}
+	}
+}
+
+// copyCat copies d and attaches its breed when expand asks for it.
+// The caller must hold r.mu.
+func (r *MemoryRepository) copyCat(d *Cat, expand list.Expand) *Cat {
+	cat := *d
+	if b, ok := r.breeds[d.BreedID]; ok && expand.Breed {
+		breed := *b
+		cat.Breed = &breed
+	}
+	return &cat
+}
+
+// expandBreeder attaches the breeder summary when expand asks for it
+func (r *MemoryRepository) expandBreeder(ctx context.Context, d *Cat, expand list.Expand) {
+	if !expand.Breeder || d.BreederID == 0 || r.breeders == nil {
+		return
+	}
+	if b, err := r.breeders.GetBreederByID(ctx, d.BreederID); err == nil {
+		d.Breeder = b.Summary()
+	}
+}
+
+// checkBreed enforces the breed_id foreign key. The caller must hold r.mu.
+func (r *MemoryRepository) checkBreed(cat *Cat) error {
+	if _, ok := r.breeds[cat.BreedID]; cat.BreedID != 0 && !ok {
+		return apperr.New(apperr.Validation, "cat references a record that does not exist")
+	}
+	return nil
+}
+
+// checkBreeder enforces the breeder_id foreign key
+func (r *MemoryRepository) checkBreeder(ctx context.Context, cat *Cat) error {
+	if cat.BreederID == 0 || r.breeders == nil {
+		return nil
+	}
+	_, err := r.breeders.GetBreederByID(ctx, cat.BreederID)
+	if apperr.Is(err, apperr.NotFound) {
+		return apperr.New(apperr.Validation, "cat references a record that does not exist")
+	}
+	return err
+}
@@ -1,6 +1,13 @@
 package cat
 
-import "time"
+import (
+	"context"
+	"go-breeders/internal/apperr"
+	"go-breeders/internal/breeder"
+	"go-breeders/internal/list"
+	"strings"
+	"time"
+)
 
 // MockRepository is a mock implementation for testing
 type MockRepository struct{}
@@ -10,8 +17,20 @@ func NewMockRepository() Repository {
 	return &MockRepository{}
 }
 
-// AllBreeds returns mock cat breed data
-func (m *MockRepository) AllBreeds() ([]*Breed, error) {
+// AllBreeds returns mock cat breed data, filtered, sorted and paged like MySQL
+func (m *MockRepository) AllBreeds(ctx context.Context, opts list.Options) ([]*Breed, int, error) {
+	breeds, total := list.Apply(m.breeds(), opts, keepBreed(opts.Filters), breedSortKey, "breed")
+	return breeds, total, nil
+}
+
+// MockFixtures returns the data MockRepository serves, for seeding a MemoryRepository
+func MockFixtures() Fixtures {
+	m := &MockRepository{}
+	return Fixtures{Breeds: m.breeds(), Cats: m.cats()}
+}
+
+// breeds returns the mock cat breed fixtures
+func (m *MockRepository) breeds() []*Breed {
 	return []*Breed{
 		{
 			ID:               1,
@@ -35,22 +54,30 @@ func (m *MockRepository) AllBreeds() ([]*Breed, error) {
 			AlternateNames:   "",
 			GeographicOrigin: "Thailand",
 		},
-	}, nil
+	}
 }
 
 // GetBreedByID returns a single mock cat breed
-func (m *MockRepository) GetBreedByID(id int) (*Breed, error) {
-	breeds, _ := m.AllBreeds()
-	for _, breed := range breeds {
+func (m *MockRepository) GetBreedByID(ctx context.Context, id int) (*Breed, error) {
+	for _, breed := range m.breeds() {
 		if breed.ID == id {
 			return breed, nil
 		}
 	}
-	return nil, nil
+	return nil, apperr.NotFoundf("cat breed %d not found", id)
+}
+
+// AllCats returns mock cat data, filtered, sorted and paged like MySQL
+func (m *MockRepository) AllCats(ctx context.Context, opts list.Options) ([]*Cat, int, error) {
+	cats, total := list.Apply(m.cats(), opts, keepCat(opts.Filters), catSortKey, "cat_name")
+	for _, c := range cats {
+		m.expand(ctx, c, opts.Expand)
+	}
+	return cats, total, nil
 }
 
-// AllCats returns mock cat data
-func (m *MockRepository) AllCats() ([]*Cat, error) {
+// cats returns the mock cat fixtures
+func (m *MockRepository) cats() []*Cat {
 	return []*Cat{
 		{
 			ID:               1,
@@ -74,31 +101,106 @@ func (m *MockRepository) AllCats() ([]*Cat, error) {
 			Description:      "Talkative Siamese",
 			Weight:           10,
 		},
-	}, nil
+	}
 }
 
 // GetCatByID returns a single mock cat
-func (m *MockRepository) GetCatByID(id int) (*Cat, error) {
-	cats, _ := m.AllCats()
-	for _, cat := range cats {
+func (m *MockRepository) GetCatByID(ctx context.Context, id int, expand list.Expand) (*Cat, error) {
+	for _, cat := range m.cats() {
 		if cat.ID == id {
-			return cat, nil
+			return m.expand(ctx, cat, expand), nil
 		}
 	}
-	return nil, nil
+	return nil, apperr.NotFoundf("cat %d not found", id)
 }
 
 // InsertCat simulates inserting a cat
-func (m *MockRepository) InsertCat(cat *Cat) (int, error) {
+func (m *MockRepository) InsertCat(ctx context.Context, cat *Cat) (int, error) {
 	return 999, nil
 }
 
 // UpdateCat simulates updating a cat
-func (m *MockRepository) UpdateCat(cat *Cat) error {
-	return nil
+func (m *MockRepository) UpdateCat(ctx context.Context, cat *Cat) error {
+	_, err := m.GetCatByID(ctx, cat.ID, list.Expand{})
+	return err
 }
 
 // DeleteCat simulates deleting a cat
-func (m *MockRepository) DeleteCat(id int) error {
-	return nil
+func (m *MockRepository) DeleteCat(ctx context.Context, id int) error {
+	_, err := m.GetCatByID(ctx, id, list.Expand{})
+	return err
+}
+
+// expand fills in the breed and breeder of c the way the MySQL joins would
+func (m *MockRepository) expand(ctx context.Context, c *Cat, expand list.Expand) *Cat {
+	if expand.Breed {
+		if b, err := m.GetBreedByID(ctx, c.BreedID); err == nil {
+			c.Breed = b
+		}
+	}
+	if expand.Breeder {
+		if b, err := breeder.NewMockRepository().GetBreederByID(ctx, c.BreederID); err == nil {
+			c.Breeder = b.Summary()
+		}
+	}
+	return c
+}
+
+// keepBreed returns the list.Apply filter for the breed filters in f
+func keepBreed(f list.Filters) func(*Breed) bool {
+	return func(b *Breed) bool {
+		return (f.Origin == "" || strings.EqualFold(b.GeographicOrigin, f.Origin)) &&
+			(f.MinWeight == 0 || b.WeightHighLbs >= f.MinWeight) &&
+			(f.MaxWeight == 0 || b.WeightLowLbs <= f.MaxWeight) &&
+			(f.MinLifespan == 0 || b.Lifespan >= f.MinLifespan) &&
+			(f.MaxLifespan == 0 || b.Lifespan <= f.MaxLifespan)
+	}
+}
+
+// keepCat returns the list.Apply filter for the cat filters in f
+func keepCat(f list.Filters) func(*Cat) bool {
+	return func(c *Cat) bool {
+		return (f.BreedID == 0 || c.BreedID == f.BreedID) &&
+			(f.BreederID == 0 || c.BreederID == f.BreederID) &&
+			(f.MinWeight == 0 || c.Weight >= f.MinWeight) &&
+			(f.MaxWeight == 0 || c.Weight <= f.MaxWeight)
+	}
+}
+
+// breedSortKey returns the value of a BreedSortFields field for list.Apply
+func breedSortKey(b *Breed, field string) any {
+	switch field {
+	case "breed":
+		return b.Breed
+	case "weight_low_lbs":
+		return b.WeightLowLbs
+	case "weight_high_lbs":
+		return b.WeightHighLbs
+	case "average_weight":
+		return b.AverageWeight
+	case "average_lifespan":
+		return b.Lifespan
+	case "geographic_origin":
+		return b.GeographicOrigin
+	default:
+		return b.ID
+	}
+}
+
+// catSortKey returns the value of a CatSortFields field for list.Apply
+func catSortKey(c *Cat, field string) any {
+	switch field {
+	case "cat_name":
+		return c.CatName
+	case "date_of_birth":
+		return int(c.DateOfBirth.Unix())
+	case "weight":
+		return c.Weight
+	case "breed_id":
+		return c.BreedID
+	case "breeder_id":
+		return c.BreederID
+	default:
+		return c.ID
+	}
 }
@@ -1,15 +1,26 @@
 package cat
 
+import (
+	"context"
+	"go-breeders/internal/list"
+)
+
+// BreedSortFields are the JSON fields AllBreeds can be sorted by
+var BreedSortFields = []string{"id", "breed", "weight_low_lbs", "weight_high_lbs", "average_weight", "average_lifespan", "geographic_origin"}
+
+// CatSortFields are the JSON fields AllCats can be sorted by
+var CatSortFields = []string{"id", "cat_name", "date_of_birth", "weight", "breed_id", "breeder_id"}
+
 // Repository defines the interface for cat data operations
 type Repository interface {
 	// Breed operations
-	AllBreeds() ([]*Breed, error)
-	GetBreedByID(id int) (*Breed, error)
+	AllBreeds(ctx context.Context, opts list.Options) ([]*Breed, int, error)
+	GetBreedByID(ctx context.Context, id int) (*Breed, error)
 
 	// Cat operations
-	AllCats() ([]*Cat, error)
-	GetCatByID(id int) (*Cat, error)
-	InsertCat(cat *Cat) (int, error)
-	UpdateCat(cat *Cat) error
-	DeleteCat(id int) error
+	AllCats(ctx context.Context, opts list.Options) ([]*Cat, int, error)
+	GetCatByID(ctx context.Context, id int, expand list.Expand) (*Cat, error)
+	InsertCat(ctx context.Context, cat *Cat) (int, error)
+	UpdateCat(ctx context.Context, cat *Cat) error
+	DeleteCat(ctx context.Context, id int) error
 }
@@ -1,46 +1,61 @@
 package cat
 
+import (
+	"context"
+	"go-breeders/internal/list"
+)
+
 // Service provides business logic for cat operations
 type Service struct {
-	repo Repository
+	repo     Repository
+	breeders BreederFinder
 }
 
-// NewService creates a new cat service
-func NewService(repo Repository) *Service {
-	return &Service{repo: repo}
+// NewService creates a new cat service; breeders is used to validate breeder IDs
+func NewService(repo Repository, breeders BreederFinder) *Service {
+	return &Service{repo: repo, breeders: breeders}
 }
 
-// GetAllBreeds returns all cat breeds
-func (s *Service) GetAllBreeds() ([]*Breed, error) {
-	return s.repo.AllBreeds()
+// GetAllBreeds returns one page of cat breeds and the total number of matches
+func (s *Service) GetAllBreeds(ctx context.Context, opts list.Options) ([]*Breed, int, error) {
+	return s.repo.AllBreeds(ctx, opts)
 }
 
 // GetBreedByID returns a specific cat breed
-func (s *Service) GetBreedByID(id int) (*Breed, error) {
-	return s.repo.GetBreedByID(id)
+func (s *Service) GetBreedByID(ctx context.Context, id int) (*Breed, error) {
+	return s.repo.GetBreedByID(ctx, id)
 }
 
-// GetAllCats returns all cats
-func (s *Service) GetAllCats() ([]*Cat, error) {
-	return s.repo.AllCats()
+// GetAllCats returns one page of cats and the total number of matches
+func (s *Service) GetAllCats(ctx context.Context, opts list.Options) ([]*Cat, int, error) {
+	return s.repo.AllCats(ctx, opts)
 }
 
-// GetCatByID returns a specific cat
-func (s *Service) GetCatByID(id int) (*Cat, error) {
-	return s.repo.GetCatByID(id)
+// GetCatByID returns a specific cat with the related records in expand
+func (s *Service) GetCatByID(ctx context.Context, id int, expand list.Expand) (*Cat, error) {
+	return s.repo.GetCatByID(ctx, id, expand)
 }
 
-// CreateCat creates a new cat
-func (s *Service) CreateCat(cat *Cat) (int, error) {
-	return s.repo.InsertCat(cat)
+// CreateCat validates and creates a new cat
+func (s *Service) CreateCat(ctx context.Context, cat *Cat) (int, error) {
+	if err := s.validateCat(ctx, cat); err != nil {
+		return 0, err
+	}
+	return s.repo.InsertCat(ctx, cat)
 }
 
-// UpdateCat updates an existing cat
-func (s *Service) UpdateCat(cat *Cat) error {
-	return s.repo.UpdateCat(cat)
+// UpdateCat validates and updates an existing cat
+func (s *Service) UpdateCat(ctx context.Context, cat *Cat) error {
+	if _, err := s.repo.GetCatByID(ctx, cat.ID, list.Expand{}); err != nil {
+		return err
+	}
+	if err := s.validateCat(ctx, cat); err != nil {
+		return err
+	}
+	return s.repo.UpdateCat(ctx, cat)
 }
 
-// DeleteCat deletes a cat
-func (s *Service) DeleteCat(id int) error {
-	return s.repo.DeleteCat(id)
+// DeleteCat deletes a cat; the repository reports NotFound for a missing ID
+func (s *Service) DeleteCat(ctx context.Context, id int) error {
+	return s.repo.DeleteCat(ctx, id)
 }
@@ -0,0 +1,342 @@
+package cat
+
+import (
+	"context"
+	"go-breeders/internal/apperr"
+	"go-breeders/internal/breeder"
+	"go-breeders/internal/database"
+	"go-breeders/internal/list"
+	"time"
+)
+
+// defaultQueryTimeout bounds each query when no timeout is configured
+const defaultQueryTimeout = 3 * time.Second
+
+// SQLRepository is the implementation of Repository for MySQL, PostgreSQL
+// and SQLite. Queries are written once; Dialect covers where they differ.
+type SQLRepository struct {
+	DB           database.DBTX    // a *sql.DB, or a *sql.Tx inside a unit of work
+	QueryTimeout time.Duration    // per-query deadline, on top of the caller's context
+	Dialect      database.Dialect // the SQL flavour of DB's driver
+}
+
+// NewMySQLRepository creates a new MySQL repository for cats.
+// queryTimeout bounds each query; zero uses the 3 second default.
+func NewMySQLRepository(db database.DBTX, queryTimeout time.Duration) Repository {
+	return newSQLRepository(db, queryTimeout, database.MySQLDialect)
+}
+
+// NewPostgresRepository creates a new PostgreSQL repository for cats
+func NewPostgresRepository(db database.DBTX, queryTimeout time.Duration) Repository {
+	return newSQLRepository(db, queryTimeout, database.PostgresDialect)
+}
+
+// NewSQLiteRepository creates a new SQLite repository for cats
+func NewSQLiteRepository(db database.DBTX, queryTimeout time.Duration) Repository {
+	return newSQLRepository(db, queryTimeout, database.SQLiteDialect)
+}
+
+// newSQLRepository creates a repository whose queries are written for dialect
+func newSQLRepository(db database.DBTX, queryTimeout time.Duration, dialect database.Dialect) *SQLRepository {
+	if queryTimeout <= 0 {
+		queryTimeout = defaultQueryTimeout
+	}
+	return &SQLRepository{DB: db, QueryTimeout: queryTimeout, Dialect: dialect}
+}
+
+// withTimeout derives the context for one query. It is cancelled when the
+// caller's context is (client disconnect, server timeout) or after QueryTimeout.
+func (r *SQLRepository) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, r.QueryTimeout)
+}
+
+// AllBreeds returns one page of cat breeds and the total number of matches
+func (r *SQLRepository) AllBreeds(ctx context.Context, opts list.Options) ([]*Breed, int, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	where := breedWhere(opts.Filters)
+
+	var total int
+	countQuery := r.Dialect.Rebind(`SELECT COUNT(*) FROM cat_breeds` + where.SQL())
+	if err := r.DB.QueryRowContext(ctx, countQuery, where.Args()...).Scan(&total); err != nil {
+		return nil, 0, apperr.FromSQL(err, "cat breed")
+	}
+
+	query := r.Dialect.Rebind(r.breedSelect() + where.SQL() +
+		list.OrderBy(opts, breedSortColumns, "breed") + ` LIMIT ? OFFSET ?`)
+
+	rows, err := r.DB.QueryContext(ctx, query, append(where.Args(), opts.Limit(), opts.Offset())...)
+	if err != nil {
+		return nil, 0, apperr.FromSQL(err, "cat breed")
+	}
+	defer rows.Close()
+
+	var breeds []*Breed
+	for rows.Next() {
+		var b Breed
+		err := rows.Scan(
+			&b.ID, &b.Breed, &b.WeightLowLbs, &b.WeightHighLbs,
+			&b.AverageWeight, &b.Lifespan, &b.Details,
+			&b.AlternateNames, &b.GeographicOrigin,
+		)
+		if err != nil {
+			return nil, 0, apperr.FromSQL(err, "cat breed")
+		}
+		breeds = append(breeds, &b)
+	}
+
+	return breeds, total, apperr.FromSQL(rows.Err(), "cat breed")
+}
+
+// GetBreedByID returns a single cat breed by ID
+func (r *SQLRepository) GetBreedByID(ctx context.Context, id int) (*Breed, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := r.Dialect.Rebind(r.breedSelect() + ` WHERE id = ?`)
+
+	var breed Breed
+	err := r.DB.QueryRowContext(ctx, query, id).Scan(
+		&breed.ID, &breed.Breed, &breed.WeightLowLbs, &breed.WeightHighLbs,
+		&breed.AverageWeight, &breed.Lifespan, &breed.Details,
+		&breed.AlternateNames, &breed.GeographicOrigin,
+	)
+	if err != nil {
+		return nil, apperr.FromSQL(err, "cat breed")
+	}
+
+	return &breed, nil
+}
+
+// AllCats returns one page of cats and the total number of matches.
+// opts.Expand joins in the breed and breeder in the same query.
+func (r *SQLRepository) AllCats(ctx context.Context, opts list.Options) ([]*Cat, int, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	where := catWhere(opts.Filters)
+
+	var total int
+	countQuery := r.Dialect.Rebind(`SELECT COUNT(*) FROM cats c` + where.SQL())
+	if err := r.DB.QueryRowContext(ctx, countQuery, where.Args()...).Scan(&total); err != nil {
+		return nil, 0, apperr.FromSQL(err, "cat")
+	}
+
+	query := r.Dialect.Rebind(r.catSelect(opts.Expand) + where.SQL() +
+		list.OrderBy(opts, catSortColumns, "c.cat_name") + ` LIMIT ? OFFSET ?`)
+
+	rows, err := r.DB.QueryContext(ctx, query, append(where.Args(), opts.Limit(), opts.Offset())...)
+	if err != nil {
+		return nil, 0, apperr.FromSQL(err, "cat")
+	}
+	defer rows.Close()
+
+	var cats []*Cat
+	for rows.Next() {
+		c, err := scanCat(rows, opts.Expand)
+		if err != nil {
+			return nil, 0, apperr.FromSQL(err, "cat")
+		}
+		cats = append(cats, c)
+	}
+
+	return cats, total, apperr.FromSQL(rows.Err(), "cat")
+}
+
+// GetCatByID returns a single cat by ID, joining in the related records in expand
+func (r *SQLRepository) GetCatByID(ctx context.Context, id int, expand list.Expand) (*Cat, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := r.Dialect.Rebind(r.catSelect(expand) + ` WHERE c.id = ?`)
+
+	cat, err := scanCat(r.DB.QueryRowContext(ctx, query, id), expand)
+	if err != nil {
+		return nil, apperr.FromSQL(err, "cat")
+	}
+
+	return cat, nil
+}
+
+// InsertCat inserts a new cat and returns the ID.
+// A zero breed or breeder ID is stored as NULL.
+func (r *SQLRepository) InsertCat(ctx context.Context, cat *Cat) (int, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `INSERT INTO cats (cat_name, breed_id, breeder_id, color,
+			date_of_birth, spayed_neutered, description, weight)
+			VALUES (?, NULLIF(?, 0), NULLIF(?, 0), ?, ?, ?, ?, ?)`
+
+	id, err := r.Dialect.Insert(ctx, r.DB, query,
+		cat.CatName, cat.BreedID, cat.BreederID, cat.Color,
+		cat.DateOfBirth, cat.SpayedOrNeutered, cat.Description, cat.Weight,
+	)
+	if err != nil {
+		return 0, apperr.FromSQL(err, "cat")
+	}
+
+	return id, nil
+}
+
+// UpdateCat updates an existing cat, or returns NotFound
+func (r *SQLRepository) UpdateCat(ctx context.Context, cat *Cat) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `UPDATE cats SET cat_name = ?, breed_id = NULLIF(?, 0),
+			breeder_id = NULLIF(?, 0), color = ?, date_of_birth = ?,
+			spayed_neutered = ?, description = ?, weight = ? WHERE id = ?`
+
+	result, err := r.DB.ExecContext(ctx, r.Dialect.Rebind(query),
+		cat.CatName, cat.BreedID, cat.BreederID, cat.Color,
+		cat.DateOfBirth, cat.SpayedOrNeutered, cat.Description,
+		cat.Weight, cat.ID,
+	)
+
+	return apperr.FromResult(result, err, "cat")
+}
+
+// DeleteCat deletes a cat by ID, or returns NotFound
+func (r *SQLRepository) DeleteCat(ctx context.Context, id int) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := r.Dialect.Rebind(`DELETE FROM cats WHERE id = ?`)
+	result, err := r.DB.ExecContext(ctx, query, id)
+	return apperr.FromResult(result, err, "cat")
+}
+
+// breedSortColumns maps BreedSortFields to SQL expressions
+var breedSortColumns = map[string]string{
+	"id":                "id",
+	"breed":             "breed",
+	"weight_low_lbs":    "weight_low_lbs",
+	"weight_high_lbs":   "weight_high_lbs",
+	"average_weight":    "weight_low_lbs + weight_high_lbs", // same order as the average, without integer division
+	"average_lifespan":  "lifespan",
+	"geographic_origin": "geographic_origin",
+}
+
+// catSortColumns maps CatSortFields to SQL columns
+var catSortColumns = map[string]string{
+	"id":            "c.id",
+	"cat_name":      "c.cat_name",
+	"date_of_birth": "c.date_of_birth",
+	"weight":        "c.weight",
+	"breed_id":      "c.breed_id",
+	"breeder_id":    "c.breeder_id",
+}
+
+// breedWhere builds the WHERE clause for the breed filters in f. Text filters compare
+// lowercased, since only MySQL's collation ignores case.
+func breedWhere(f list.Filters) *list.Where {
+	var where list.Where
+	if f.Origin != "" {
+		where.Add("LOWER(geographic_origin) = LOWER(?)", f.Origin)
+	}
+	if f.MinWeight > 0 {
+		where.Add("weight_high_lbs >= ?", f.MinWeight)
+	}
+	if f.MaxWeight > 0 {
+		where.Add("weight_low_lbs <= ?", f.MaxWeight)
+	}
+	if f.MinLifespan > 0 {
+		where.Add("lifespan >= ?", f.MinLifespan)
+	}
+	if f.MaxLifespan > 0 {
+		where.Add("lifespan <= ?", f.MaxLifespan)
+	}
+	return &where
+}
+
+// catWhere builds the WHERE clause for the cat filters in f
+func catWhere(f list.Filters) *list.Where {
+	var where list.Where
+	if f.BreedID > 0 {
+		where.Add("c.breed_id = ?", f.BreedID)
+	}
+	if f.BreederID > 0 {
+		where.Add("c.breeder_id = ?", f.BreederID)
+	}
+	if f.MinWeight > 0 {
+		where.Add("c.weight >= ?", f.MinWeight)
+	}
+	if f.MaxWeight > 0 {
+		where.Add("c.weight <= ?", f.MaxWeight)
+	}
+	return &where
+}
+
+// breedSelect returns the SELECT for cat breeds; rows scan in Breed field order
+func (r *SQLRepository) breedSelect() string {
+	return `SELECT id, breed, weight_low_lbs, weight_high_lbs, ` +
+		r.Dialect.RoundInt("(weight_low_lbs + weight_high_lbs) / 2.0") + ` AS average_weight,
+			lifespan, COALESCE(details, ''),
+			COALESCE(alternate_names, ''), COALESCE(geographic_origin, '')
+			FROM cat_breeds`
+}
+
+// catSelect returns the SELECT for cats (aliased c) with the breed (b) and
+// breeder (br) LEFT JOINed when expand asks for them, so listing cats with
+// their relations costs one query rather than one per cat
+func (r *SQLRepository) catSelect(expand list.Expand) string {
+	columns := `c.id, c.cat_name, COALESCE(c.breed_id, 0), COALESCE(c.breeder_id, 0),
+			c.color, c.date_of_birth, c.spayed_neutered, c.description, c.weight`
+	joins := ""
+
+	if expand.Breed {
+		columns += `, COALESCE(b.id, 0), COALESCE(b.breed, ''),
+			COALESCE(b.weight_low_lbs, 0), COALESCE(b.weight_high_lbs, 0),
+			COALESCE(` + r.Dialect.RoundInt("(b.weight_low_lbs + b.weight_high_lbs) / 2.0") + `, 0),
+			COALESCE(b.lifespan, 0), COALESCE(b.details, ''),
+			COALESCE(b.alternate_names, ''), COALESCE(b.geographic_origin, '')`
+		joins += ` LEFT JOIN cat_breeds b ON b.id = c.breed_id`
+	}
+
+	if expand.Breeder {
+		columns += `, COALESCE(br.id, 0), COALESCE(br.breeder_name, ''),
+			COALESCE(br.city, ''), COALESCE(br.prov_state, ''), COALESCE(br.country, '')`
+		joins += ` LEFT JOIN breeders br ON br.id = c.breeder_id`
+	}
+
+	return `SELECT ` + columns + ` FROM cats c` + joins
+}
+
+// scanCat scans a row produced by catSelect with the same expand
+func scanCat(row interface{ Scan(...any) error }, expand list.Expand) (*Cat, error) {
+	var c Cat
+	var b Breed
+	var br breeder.Summary
+
+	dest := []any{
+		&c.ID, &c.CatName, &c.BreedID, &c.BreederID,
+		&c.Color, &c.DateOfBirth, &c.SpayedOrNeutered,
+		&c.Description, &c.Weight,
+	}
+	if expand.Breed {
+		dest = append(dest,
+			&b.ID, &b.Breed, &b.WeightLowLbs, &b.WeightHighLbs,
+			&b.AverageWeight, &b.Lifespan, &b.Details,
+			&b.AlternateNames, &b.GeographicOrigin,
+		)
+	}
+	if expand.Breeder {
+		dest = append(dest, &br.ID, &br.BreederName, &br.City, &br.ProvState, &br.Country)
+	}
+
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	// a zero ID means the LEFT JOIN found nothing (the reference is NULL)
+	if b.ID != 0 {
+		c.Breed = &b
+	}
+	if br.ID != 0 {
+		c.Breeder = &br
+	}
+
+	return &c, nil
+}
@@ -0,0 +1,55 @@
+package cat
+
+import (
+	"context"
+	"go-breeders/internal/apperr"
+	"go-breeders/internal/breeder"
+	"go-breeders/internal/validate"
+)
+
+// weightTolerance is how far outside its breed's weight range a cat may be
+// (as a fraction of the bounds) before the weight is rejected
+const weightTolerance = 0.25
+
+// BreederFinder is the part of breeder.Repository the cat service needs to
+// confirm that a cat's breeder exists
+type BreederFinder interface {
+	GetBreederByID(ctx context.Context, id int) (*breeder.Breeder, error)
+}
+
+// validateCat checks cat against the field rules and confirms that the
+// referenced breed and breeder exist
+func (s *Service) validateCat(ctx context.Context, cat *Cat) error {
+	v := validate.New()
+
+	v.Required("cat_name", cat.CatName)
+	v.MaxLength("cat_name", cat.CatName, 255)
+	v.MaxLength("color", cat.Color, 255)
+	v.Past("date_of_birth", cat.DateOfBirth)
+	v.Check(cat.Weight > 0, "weight", "must be greater than zero")
+	v.OneOf("spayed_neutered", cat.SpayedOrNeutered, 0, 1)
+
+	if cat.BreedID != 0 {
+		breed, err := s.repo.GetBreedByID(ctx, cat.BreedID)
+		switch {
+		case apperr.Is(err, apperr.NotFound):
+			v.Check(false, "breed_id", "does not exist")
+		case err != nil:
+			return err
+		case cat.Weight > 0:
+			v.WithinTolerance("weight", cat.Weight, breed.WeightLowLbs, breed.WeightHighLbs, weightTolerance)
+		}
+	}
+
+	if cat.BreederID != 0 && s.breeders != nil {
+		_, err := s.breeders.GetBreederByID(ctx, cat.BreederID)
+		switch {
+		case apperr.Is(err, apperr.NotFound):
+			v.Check(false, "breeder_id", "does not exist")
+		case err != nil:
+			return err
+		}
+	}
+
+	return v.Err()
+}
@@ -0,0 +1,76 @@
+package database
+
+import "testing"
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name           string
+		driver         string
+		dsn            string
+		expectedDriver string
+		expectedDSN    string
+	}{
+		{"mysql default", "mysql", "", MySQL,
+			"mariadb:myverysecretpassword@tcp(localhost:3306)/breeders?clientFoundRows=true&collation=utf8_unicode_ci&parseTime=true&timeout=5s&tls=false"},
+		{"postgres scheme", "mysql", "postgres://u:p@db/breeders", Postgres, "postgres://u:p@db/breeders"},
+		{"sqlite scheme", "mysql", "sqlite://data/breeders.db", SQLite,
+			"data/breeders.db?_pragma=foreign_keys%281%29&_pragma=busy_timeout%285000%29&_pragma=journal_mode%28WAL%29"},
+		{"sqlite driver default", "sqlite", "", SQLite,
+			"breeders.db?_pragma=foreign_keys%281%29&_pragma=busy_timeout%285000%29&_pragma=journal_mode%28WAL%29"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			driver, dsn, err := Resolve(tt.driver, tt.dsn)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if driver != tt.expectedDriver {
+				t.Errorf("wrong driver: got %q want %q", driver, tt.expectedDriver)
+			}
+			if dsn != tt.expectedDSN {
+				t.Errorf("wrong dsn: got %q want %q", dsn, tt.expectedDSN)
+			}
+		})
+	}
+
+	if _, _, err := Resolve("oracle", ""); err == nil {
+		t.Error("expected an error for an unknown driver")
+	}
+}
+
+func TestRebind(t *testing.T) {
+	tests := []struct{ query, expected string }{
+		{"SELECT 1", "SELECT 1"},
+		{"SELECT * FROM dogs WHERE id = ?", "SELECT * FROM dogs WHERE id = $1"},
+		{"UPDATE dogs SET dog_name = ?, weight = ? WHERE id = ?", "UPDATE dogs SET dog_name = $1, weight = $2 WHERE id = $3"},
+	}
+
+	for _, tt := range tests {
+		if got := Rebind(tt.query); got != tt.expected {
+			t.Errorf("Rebind(%q) = %q, want %q", tt.query, got, tt.expected)
+		}
+	}
+}
+
+func TestDialect(t *testing.T) {
+	query := "SELECT id FROM dogs WHERE breed_id = ? AND weight <= ?"
+	tests := []struct {
+		dialect       Dialect
+		expectedQuery string
+		expectedRound string
+	}{
+		{MySQLDialect, query, "CAST(x / 2.0 AS unsigned)"},
+		{PostgresDialect, "SELECT id FROM dogs WHERE breed_id = $1 AND weight <= $2", "(x / 2.0)::int"},
+		{SQLiteDialect, query, "CAST(ROUND(x / 2.0) AS INTEGER)"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.dialect.Rebind(query); got != tt.expectedQuery {
+			t.Errorf("%s: Rebind = %q, want %q", tt.dialect.Name, got, tt.expectedQuery)
+		}
+		if got := tt.dialect.RoundInt("x / 2.0"); got != tt.expectedRound {
+			t.Errorf("%s: RoundInt = %q, want %q", tt.dialect.Name, got, tt.expectedRound)
+		}
+	}
+}
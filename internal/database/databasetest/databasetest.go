@@ -0,0 +1,77 @@
+// Package databasetest opens migrated databases for repository tests.
+package databasetest
+
+import (
+	"context"
+	"database/sql"
+	"go-breeders/internal/database"
+	"go-breeders/internal/migrate"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Environment variables holding the DSNs of servers to run the SQL tests against.
+// The tests for a backend are skipped when its variable is unset.
+const (
+	MySQLDSNEnv    = "BREEDERS_TEST_MYSQL_DSN"
+	PostgresDSNEnv = "BREEDERS_TEST_POSTGRES_DSN"
+)
+
+// SQLite returns a migrated and seeded SQLite database in a temp dir
+func SQLite(t *testing.T) *sql.DB {
+	t.Helper()
+	return open(t, database.SQLite, "sqlite://"+filepath.Join(t.TempDir(), "breeders.db"))
+}
+
+// MySQL returns the migrated database named by BREEDERS_TEST_MYSQL_DSN, or skips the test.
+// It is shared, so tests must clean up the rows they insert.
+func MySQL(t *testing.T) *sql.DB {
+	t.Helper()
+	return open(t, database.MySQL, fromEnv(t, MySQLDSNEnv))
+}
+
+// Postgres returns the migrated database named by BREEDERS_TEST_POSTGRES_DSN, or skips the test.
+// It is shared, so tests must clean up the rows they insert.
+func Postgres(t *testing.T) *sql.DB {
+	t.Helper()
+	return open(t, database.Postgres, fromEnv(t, PostgresDSNEnv))
+}
+
+func fromEnv(t *testing.T, name string) string {
+	t.Helper()
+	dsn := os.Getenv(name)
+	if dsn == "" {
+		t.Skipf("%s not set", name)
+	}
+	return dsn
+}
+
+func open(t *testing.T, driver, dsn string) *sql.DB {
+	t.Helper()
+
+	driver, dsn, err := database.Resolve(driver, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	dialect, err := migrate.ForDriver(driver)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := migrate.New(db, dialect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Up(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
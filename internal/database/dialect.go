@@ -0,0 +1,82 @@
+package database
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// Dialect is where the SQL of the supported drivers differs, so the
+// repositories can write each query once with "?" placeholders
+type Dialect struct {
+	// Name is the driver name, e.g. Postgres
+	Name string
+
+	numbered  bool // placeholders are $1, $2, ... rather than ?
+	returning bool // new IDs are read with RETURNING id, as lib/pq has no LastInsertId
+	roundInt  func(expr string) string
+}
+
+// Dialects of the supported drivers
+var (
+	MySQLDialect = Dialect{
+		Name:     MySQL,
+		roundInt: func(expr string) string { return "CAST(" + expr + " AS unsigned)" },
+	}
+	PostgresDialect = Dialect{
+		Name:      Postgres,
+		numbered:  true,
+		returning: true,
+		roundInt:  func(expr string) string { return "(" + expr + ")::int" },
+	}
+	SQLiteDialect = Dialect{
+		Name:     SQLite,
+		roundInt: func(expr string) string { return "CAST(ROUND(" + expr + ") AS INTEGER)" },
+	}
+)
+
+// Rebind rewrites the "?" placeholders of query for the driver
+func (d Dialect) Rebind(query string) string {
+	if !d.numbered {
+		return query
+	}
+	return Rebind(query)
+}
+
+// RoundInt returns SQL rounding the numeric expr to the nearest integer
+func (d Dialect) RoundInt(expr string) string {
+	return d.roundInt(expr)
+}
+
+// Insert runs query, an INSERT of one row, and returns the row's new id
+func (d Dialect) Insert(ctx context.Context, db DBTX, query string, args ...any) (int, error) {
+	if d.returning {
+		var id int
+		err := db.QueryRowContext(ctx, d.Rebind(query)+` RETURNING id`, args...).Scan(&id)
+		return id, err
+	}
+
+	result, err := db.ExecContext(ctx, d.Rebind(query), args...)
+	if err != nil {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	return int(id), err
+}
+
+// Rebind rewrites "?" placeholders as PostgreSQL's $1, $2, ... so one query
+// text can serve every driver. Queries must not contain a literal "?"
+// outside of placeholders.
+func Rebind(query string) string {
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r != '?' {
+			b.WriteRune(r)
+			continue
+		}
+		n++
+		b.WriteString("$" + strconv.Itoa(n))
+	}
+	return b.String()
+}
@@ -0,0 +1,80 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// Pool configures a *sql.DB connection pool and how Open waits for the server
+type Pool struct {
+	MaxOpen      int           // open connections, in use plus idle; zero means no limit
+	MaxIdle      int           // idle connections kept for reuse; capped at MaxOpen
+	MaxLifetime  time.Duration // close connections after this long; zero keeps them
+	MaxIdleTime  time.Duration // close connections idle for this long; zero keeps them
+	PingAttempts int           // pings before Open gives up; at least one is made
+	PingBackoff  time.Duration // wait after the first failed ping; doubles after each one
+}
+
+// DefaultPool is the pool used unless flags or the environment say otherwise
+var DefaultPool = Pool{
+	MaxOpen:      25,
+	MaxIdle:      25,
+	MaxLifetime:  5 * time.Minute,
+	PingAttempts: 5,
+	PingBackoff:  time.Second,
+}
+
+// pingTimeout bounds a single ping, and maxPingBackoff the wait between two
+const (
+	pingTimeout    = 5 * time.Second
+	maxPingBackoff = 30 * time.Second
+)
+
+// Apply sets the pool limits on db
+func (p Pool) Apply(db *sql.DB) {
+	db.SetMaxOpenConns(p.MaxOpen)
+	db.SetMaxIdleConns(p.MaxIdle)
+	db.SetConnMaxLifetime(p.MaxLifetime)
+	db.SetConnMaxIdleTime(p.MaxIdleTime)
+}
+
+// Open opens a pool for a driver and DSN from Resolve, applies p and pings
+// until the server answers, so the app can start before its database is up
+func Open(ctx context.Context, driver, dsn string, p Pool) (*sql.DB, error) {
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	p.Apply(db)
+
+	if err := ping(ctx, db, p); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// ping pings db up to p.PingAttempts times with exponential backoff
+func ping(ctx context.Context, db *sql.DB, p Pool) error {
+	backoff := p.PingBackoff
+	for attempt := 1; ; attempt++ {
+		pingCtx, cancel := context.WithTimeout(ctx, pingTimeout)
+		err := db.PingContext(pingCtx)
+		cancel()
+		if err == nil {
+			return nil
+		}
+		if attempt >= p.PingAttempts {
+			return fmt.Errorf("database not reachable after %d attempt(s): %w", attempt, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, maxPingBackoff)
+	}
+}
@@ -0,0 +1,41 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestOpen(t *testing.T) {
+	pool := Pool{MaxOpen: 7, MaxIdle: 3, MaxLifetime: time.Minute, PingAttempts: 1}
+
+	db, err := Open(context.Background(), SQLite, ":memory:", pool)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if got := db.Stats().MaxOpenConnections; got != pool.MaxOpen {
+		t.Errorf("max open connections: got %d want %d", got, pool.MaxOpen)
+	}
+}
+
+func TestOpen_RetriesPing(t *testing.T) {
+	// nothing listens on port 1, so every ping is refused straight away
+	_, dsn, err := Resolve(MySQL, "user:pass@tcp(127.0.0.1:1)/breeders?timeout=1s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool := DefaultPool
+	pool.PingAttempts, pool.PingBackoff = 3, 10*time.Millisecond
+
+	start := time.Now()
+	_, err = Open(context.Background(), MySQL, dsn, pool)
+	if err == nil {
+		t.Fatal("expected an unreachable server to fail")
+	}
+	// the waits between the three pings are 10ms and 20ms
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("expected Open to back off between pings, took %v", elapsed)
+	}
+}
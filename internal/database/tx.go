@@ -0,0 +1,57 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"go-breeders/internal/apperr"
+	"time"
+)
+
+// DBTX is the part of *sql.DB and *sql.Tx the repositories use, so the same
+// repository runs inside and outside a transaction
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// TxAttempts is how often InTx runs a transaction that keeps failing with a
+// deadlock or serialization error before giving up
+const TxAttempts = 3
+
+// txBackoff is the wait before the first retry; it doubles for each one after
+var txBackoff = 50 * time.Millisecond
+
+// InTx runs fn in a transaction, committing when fn returns nil and rolling
+// back otherwise. A transaction that fails with a deadlock, serialization
+// failure or busy database is run again from the start, so fn must not keep
+// state from an earlier attempt.
+func InTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	for attempt := 1; ; attempt++ {
+		err := runTx(ctx, db, fn)
+		if err == nil || attempt == TxAttempts || !apperr.Retryable(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(txBackoff << (attempt - 1)):
+		}
+	}
+}
+
+// runTx makes one attempt at InTx
+func runTx(ctx context.Context, db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return apperr.FromSQL(err, "transaction")
+	}
+	// a no-op once committed; otherwise also covers fn panicking
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return apperr.FromSQL(tx.Commit(), "transaction")
+}
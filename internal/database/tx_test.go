@@ -0,0 +1,112 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+func TestInTx(t *testing.T) {
+	db, err := sql.Open(SQLite, ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	// one connection, so every transaction sees the same in-memory database
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(`CREATE TABLE things (name TEXT)`); err != nil {
+		t.Fatal(err)
+	}
+
+	saved := txBackoff
+	txBackoff = 0
+	t.Cleanup(func() { txBackoff = saved })
+
+	ctx := context.Background()
+	insert := func(tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, `INSERT INTO things (name) VALUES ('thing')`)
+		return err
+	}
+	count := func() int {
+		var n int
+		if err := db.QueryRow(`SELECT COUNT(*) FROM things`).Scan(&n); err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+
+	t.Run("commits", func(t *testing.T) {
+		before := count()
+		if err := InTx(ctx, db, insert); err != nil {
+			t.Fatal(err)
+		}
+		if got := count(); got != before+1 {
+			t.Errorf("expected %d rows, got %d", before+1, got)
+		}
+	})
+
+	t.Run("rolls back on error", func(t *testing.T) {
+		before := count()
+		boom := errors.New("boom")
+		err := InTx(ctx, db, func(tx *sql.Tx) error {
+			if err := insert(tx); err != nil {
+				return err
+			}
+			return boom
+		})
+		if !errors.Is(err, boom) {
+			t.Fatalf("expected the callback's error, got %v", err)
+		}
+		if got := count(); got != before {
+			t.Errorf("expected the insert to be rolled back, got %d rows want %d", got, before)
+		}
+	})
+
+	t.Run("retries deadlocks", func(t *testing.T) {
+		before, calls := count(), 0
+		err := InTx(ctx, db, func(tx *sql.Tx) error {
+			calls++
+			if err := insert(tx); err != nil {
+				return err
+			}
+			if calls == 1 {
+				return &mysql.MySQLError{Number: 1213, Message: "Deadlock found"}
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if calls != 2 {
+			t.Errorf("expected 2 attempts, got %d", calls)
+		}
+		if got := count(); got != before+1 {
+			t.Errorf("expected only the retried insert to be kept, got %d rows want %d", got, before+1)
+		}
+	})
+
+	t.Run("gives up after TxAttempts", func(t *testing.T) {
+		calls := 0
+		err := InTx(ctx, db, func(tx *sql.Tx) error {
+			calls++
+			return &mysql.MySQLError{Number: 1213, Message: "Deadlock found"}
+		})
+		if err == nil || calls != TxAttempts {
+			t.Errorf("expected %d attempts and an error, got %d and %v", TxAttempts, calls, err)
+		}
+	})
+
+	t.Run("does not retry other errors", func(t *testing.T) {
+		calls := 0
+		_ = InTx(ctx, db, func(tx *sql.Tx) error {
+			calls++
+			return &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}
+		})
+		if calls != 1 {
+			t.Errorf("expected 1 attempt, got %d", calls)
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package dog
+
+import (
+	"context"
+	"go-breeders/internal/cache"
+	"go-breeders/internal/list"
+	"time"
+)
+
+// breedPage is one cached AllBreeds result
+type breedPage struct {
+	breeds []*Breed
+	total  int
+}
+
+// CachedRepository is a Repository decorator that caches breed lookups.
+// Repository has no breed writes (the tables change through migrations), so
+// entries are only refreshed when their TTL runs out.
+// Dog operations pass straight through to the wrapped repository.
+type CachedRepository struct {
+	Repository
+	pages *cache.Cache[list.Options, breedPage]
+	byID  *cache.Cache[int, *Breed]
+}
+
+// NewCachedRepository wraps repo, keeping at most size breed pages and size
+// single breeds for ttl each
+func NewCachedRepository(repo Repository, ttl time.Duration, size int) *CachedRepository {
+	return &CachedRepository{
+		Repository: repo,
+		pages:      cache.New[list.Options, breedPage](ttl, size),
+		byID:       cache.New[int, *Breed](ttl, size),
+	}
+}
+
+// AllBreeds returns one page of dog breeds, from the cache when possible
+func (r *CachedRepository) AllBreeds(ctx context.Context, opts list.Options) ([]*Breed, int, error) {
+	// only breed options matter, and the Active pointer would make every key unique
+	key := opts
+	key.Filters.Active, key.Expand = nil, list.Expand{}
+
+	page, err := r.pages.Load(key, func() (breedPage, error) {
+		breeds, total, err := r.Repository.AllBreeds(ctx, opts)
+		return breedPage{breeds: breeds, total: total}, err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	breeds := make([]*Breed, len(page.breeds))
+	for i, b := range page.breeds {
+		breed := *b
+		breeds[i] = &breed
+	}
+	return breeds, page.total, nil
+}
+
+// GetBreedByID returns a single dog breed, from the cache when possible
+func (r *CachedRepository) GetBreedByID(ctx context.Context, id int) (*Breed, error) {
+	b, err := r.byID.Load(id, func() (*Breed, error) {
+		return r.Repository.GetBreedByID(ctx, id)
+	})
+	if err != nil {
+		return nil, err
+	}
+	breed := *b
+	return &breed, nil
+}
+
+// BreedCacheStats returns the combined counters of the breed caches
+func (r *CachedRepository) BreedCacheStats() cache.Stats {
+	return r.pages.Stats().Add(r.byID.Stats())
+}
@@ -0,0 +1,43 @@
+package dog
+
+import (
+	"context"
+	"go-breeders/internal/list"
+	"testing"
+	"time"
+)
+
+func TestCachedRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewCachedRepository(NewMockRepository(), time.Minute, 10)
+	opts := list.Options{Page: 1, PerPage: 10}
+
+	first, total, err := repo.AllBreeds(ctx, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first[0].Breed = "Changed by the caller"
+
+	second, total2, err := repo.AllBreeds(ctx, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second[0].Breed == "Changed by the caller" {
+		t.Error("expected the cache to hand out copies")
+	}
+	if total != total2 {
+		t.Errorf("totals differ: %d and %d", total, total2)
+	}
+
+	if _, err := repo.GetBreedByID(ctx, second[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.GetBreedByID(ctx, 999); err == nil {
+		t.Error("expected a missing breed to stay missing")
+	}
+
+	stats := repo.BreedCacheStats()
+	if stats.Hits != 1 || stats.Misses != 3 || stats.Entries != 2 {
+		t.Errorf("expected 1 hit, 3 misses and 2 entries, got %+v", stats)
+	}
+}
@@ -0,0 +1,58 @@
+package dog_test
+
+import (
+	"context"
+	"go-breeders/internal/breeder"
+	"go-breeders/internal/database/databasetest"
+	"go-breeders/internal/dog"
+	"go-breeders/internal/dog/dogtest"
+	"testing"
+)
+
+func TestMockRepository_Contract(t *testing.T) {
+	dogtest.RunReadContract(t, func(t *testing.T) dogtest.Subject {
+		return dogtest.Subject{Repo: dog.NewMockRepository()}
+	})
+}
+
+func TestMemoryRepository_Contract(t *testing.T) {
+	dogtest.RunRepositoryContract(t, func(t *testing.T) dogtest.Subject {
+		breeders := breeder.NewMemoryRepository(breeder.MockFixtures())
+		return dogtest.Subject{Repo: dog.NewMemoryRepository(breeders, dog.MockFixtures()), BreederID: 1, DeleteBreeder: breeders.DeleteBreeder}
+	})
+}
+
+func TestSQLiteRepository_Contract(t *testing.T) {
+	dogtest.RunRepositoryContract(t, func(t *testing.T) dogtest.Subject {
+		db := databasetest.SQLite(t)
+		return sqlSubject(t, dog.NewSQLiteRepository(db, 0), breeder.NewSQLiteRepository(db, 0))
+	})
+}
+
+func TestMySQLRepository_Contract(t *testing.T) {
+	dogtest.RunRepositoryContract(t, func(t *testing.T) dogtest.Subject {
+		db := databasetest.MySQL(t)
+		return sqlSubject(t, dog.NewMySQLRepository(db, 0), breeder.NewMySQLRepository(db, 0))
+	})
+}
+
+func TestPostgresRepository_Contract(t *testing.T) {
+	dogtest.RunRepositoryContract(t, func(t *testing.T) dogtest.Subject {
+		db := databasetest.Postgres(t)
+		return sqlSubject(t, dog.NewPostgresRepository(db, 0), breeder.NewPostgresRepository(db, 0))
+	})
+}
+
+// sqlSubject adds a breeder for the foreign-key checks and removes it afterwards
+func sqlSubject(t *testing.T, repo dog.Repository, breeders breeder.Repository) dogtest.Subject {
+	t.Helper()
+	ctx := context.Background()
+
+	id, err := breeders.InsertBreeder(ctx, &breeder.Breeder{BreederName: "Contract Kennels", Active: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { breeders.DeleteBreeder(ctx, id) })
+
+	return dogtest.Subject{Repo: repo, BreederID: id, DeleteBreeder: breeders.DeleteBreeder}
+}
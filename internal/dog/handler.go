@@ -1,11 +1,19 @@
 package dog
 
 import (
+	"fmt"
+	"go-breeders/internal/apperr"
+	"go-breeders/internal/list"
 	"net/http"
+	"strconv"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/tsawler/toolbox"
 )
 
+// maxJSONSize caps the request body accepted by the write endpoints (1 MB)
+const maxJSONSize = 1 << 20
+
 // Handler handles HTTP requests for dog domain
 type Handler struct {
 	service *Service
@@ -16,34 +24,191 @@ func NewHandler(service *Service) *Handler {
 	return &Handler{service: service}
 }
 
-// GetAllBreedsJSON returns all dog breeds as JSON
+// GetAllBreedsJSON returns one page of dog breeds as JSON.
+// Supports ?page=, ?per_page=, ?sort= (prefix with - for descending) and filters.
 func (h *Handler) GetAllBreedsJSON(w http.ResponseWriter, r *http.Request) {
 	var t toolbox.Tools
 
-	breeds, err := h.service.GetAllBreeds()
+	opts, err := list.Parse(r, BreedSortFields)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	breeds, total, err := h.service.GetAllBreeds(r.Context(), opts)
 	if err != nil {
-		_ = t.ErrorJSON(w, err, http.StatusInternalServerError)
+		apperr.WriteJSON(w, err)
 		return
 	}
 
-	_ = t.WriteJSON(w, http.StatusOK, breeds)
+	_ = t.WriteJSON(w, http.StatusOK, list.NewPage(breeds, total, opts))
 }
 
-// GetBreedByIDJSON returns a specific dog breed as JSON
+// GetBreedByIDJSON returns the dog breed identified by the {id} URL param as JSON
 func (h *Handler) GetBreedByIDJSON(w http.ResponseWriter, r *http.Request) {
-	// Implementation would get ID from URL params
-	// This is just a placeholder for now
+	var t toolbox.Tools
+
+	id, err := idParam(r)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	breed, err := h.service.GetBreedByID(r.Context(), id)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	_ = t.WriteJSON(w, http.StatusOK, breed)
 }
 
-// GetAllDogsJSON returns all dogs as JSON
+// GetAllDogsJSON returns one page of dogs as JSON.
+// Supports ?page=, ?per_page=, ?sort= (prefix with - for descending), filters
+// and ?expand=breed,breeder.
 func (h *Handler) GetAllDogsJSON(w http.ResponseWriter, r *http.Request) {
 	var t toolbox.Tools
 
-	dogs, err := h.service.GetAllDogs()
+	opts, err := list.Parse(r, DogSortFields)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	dogs, total, err := h.service.GetAllDogs(r.Context(), opts)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	_ = t.WriteJSON(w, http.StatusOK, list.NewPage(dogs, total, opts))
+}
+
+// GetDogByIDJSON returns the dog identified by the {id} URL param as JSON.
+// Supports ?expand=breed,breeder.
+func (h *Handler) GetDogByIDJSON(w http.ResponseWriter, r *http.Request) {
+	var t toolbox.Tools
+
+	id, err := idParam(r)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	expand, err := list.ParseExpand(r)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	dog, err := h.service.GetDogByID(r.Context(), id, expand)
 	if err != nil {
-		_ = t.ErrorJSON(w, err, http.StatusInternalServerError)
+		apperr.WriteJSON(w, err)
 		return
 	}
 
-	_ = t.WriteJSON(w, http.StatusOK, dogs)
+	_ = t.WriteJSON(w, http.StatusOK, dog)
+}
+
+// CreateDogJSON creates a dog from the JSON body and returns it with a Location header
+func (h *Handler) CreateDogJSON(w http.ResponseWriter, r *http.Request) {
+	t := toolbox.Tools{MaxJSONSize: maxJSONSize}
+
+	var dog Dog
+	if err := t.ReadJSON(w, r, &dog); err != nil {
+		apperr.WriteJSON(w, apperr.New(apperr.BadRequest, err.Error()))
+		return
+	}
+
+	id, err := h.service.CreateDog(r.Context(), &dog)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+	dog.ID = id
+
+	headers := make(http.Header)
+	headers.Set("Location", fmt.Sprintf("/api/dogs/%d", id))
+	_ = t.WriteJSON(w, http.StatusCreated, dog, headers)
+}
+
+// UpdateDogJSON replaces the dog identified by the {id} URL param with the JSON body
+func (h *Handler) UpdateDogJSON(w http.ResponseWriter, r *http.Request) {
+	t := toolbox.Tools{MaxJSONSize: maxJSONSize}
+
+	id, err := idParam(r)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	var dog Dog
+	if err := t.ReadJSON(w, r, &dog); err != nil {
+		apperr.WriteJSON(w, apperr.New(apperr.BadRequest, err.Error()))
+		return
+	}
+	dog.ID = id
+
+	if err := h.service.UpdateDog(r.Context(), &dog); err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	_ = t.WriteJSON(w, http.StatusOK, dog)
+}
+
+// PatchDogJSON applies the fields present in the JSON body to an existing dog
+func (h *Handler) PatchDogJSON(w http.ResponseWriter, r *http.Request) {
+	t := toolbox.Tools{MaxJSONSize: maxJSONSize}
+
+	id, err := idParam(r)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	dog, err := h.service.GetDogByID(r.Context(), id, list.Expand{})
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	// decoding onto the stored dog leaves fields absent from the body untouched
+	if err := t.ReadJSON(w, r, dog); err != nil {
+		apperr.WriteJSON(w, apperr.New(apperr.BadRequest, err.Error()))
+		return
+	}
+	dog.ID = id
+
+	if err := h.service.UpdateDog(r.Context(), dog); err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	_ = t.WriteJSON(w, http.StatusOK, dog)
+}
+
+// DeleteDogJSON deletes the dog identified by the {id} URL param
+func (h *Handler) DeleteDogJSON(w http.ResponseWriter, r *http.Request) {
+	id, err := idParam(r)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	if err := h.service.DeleteDog(r.Context(), id); err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// idParam parses the {id} URL param as a positive integer
+func idParam(r *http.Request) (int, error) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id < 1 {
+		return 0, apperr.New(apperr.BadRequest, "invalid id")
+	}
+	return id, nil
 }
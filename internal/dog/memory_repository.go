@@ -0,0 +1,241 @@
+package dog
+
+import (
+	"context"
+	"go-breeders/internal/apperr"
+	"go-breeders/internal/list"
+	"maps"
+	"sync"
+)
+
+// Fixtures is the initial data for a MemoryRepository
+type Fixtures struct {
+	Breeds []*Breed
+	Dogs   []*Dog
+}
+
+// MemoryRepository is a thread-safe in-memory implementation of Repository.
+// It stores copies, so callers may modify what they pass in or get back.
+type MemoryRepository struct {
+	mu       sync.RWMutex
+	breeds   map[int]*Breed
+	dogs     map[int]*Dog
+	nextID   int
+	breeders BreederFinder // resolves breeder_id; nil skips the check
+}
+
+// NewMemoryRepository creates an in-memory repository for dogs seeded with
+// fixtures. breeders is used to check breeder IDs and expand breeders.
+// When breeders reports deletions, as breeder.MemoryRepository does, dogs of a
+// deleted breeder lose their breeder_id like the SQL schema's ON DELETE SET NULL.
+func NewMemoryRepository(breeders BreederFinder, fixtures Fixtures) Repository {
+	r := &MemoryRepository{
+		breeds:   make(map[int]*Breed),
+		dogs:     make(map[int]*Dog),
+		nextID:   1,
+		breeders: breeders,
+	}
+	for _, b := range fixtures.Breeds {
+		breed := *b
+		r.breeds[b.ID] = &breed
+	}
+	for _, d := range fixtures.Dogs {
+		dog := *d
+		dog.Breed, dog.Breeder = nil, nil
+		r.dogs[d.ID] = &dog
+		r.nextID = max(r.nextID, d.ID+1)
+	}
+	if d, ok := breeders.(breederDeletions); ok {
+		d.OnDelete(r.clearBreeder)
+	}
+	return r
+}
+
+// AllBreeds returns one page of dog breeds and the total number of matches
+func (r *MemoryRepository) AllBreeds(ctx context.Context, opts list.Options) ([]*Breed, int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	breeds, total := list.Apply(list.ByID(r.breeds), opts, keepBreed(opts.Filters), breedSortKey, "breed")
+	for i, b := range breeds {
+		breed := *b
+		breeds[i] = &breed
+	}
+	return breeds, total, nil
+}
+
+// GetBreedByID returns a single dog breed by ID
+func (r *MemoryRepository) GetBreedByID(ctx context.Context, id int) (*Breed, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	b, ok := r.breeds[id]
+	if !ok {
+		return nil, apperr.NotFoundf("dog breed %d not found", id)
+	}
+	breed := *b
+	return &breed, nil
+}
+
+// AllDogs returns one page of dogs and the total number of matches
+func (r *MemoryRepository) AllDogs(ctx context.Context, opts list.Options) ([]*Dog, int, error) {
+	r.mu.RLock()
+	dogs, total := list.Apply(list.ByID(r.dogs), opts, keepDog(opts.Filters), dogSortKey, "dog_name")
+	for i, d := range dogs {
+		dogs[i] = r.copyDog(d, opts.Expand)
+	}
+	r.mu.RUnlock()
+
+	for _, d := range dogs {
+		r.expandBreeder(ctx, d, opts.Expand)
+	}
+	return dogs, total, nil
+}
+
+// GetDogByID returns a single dog by ID with the related records in expand
+func (r *MemoryRepository) GetDogByID(ctx context.Context, id int, expand list.Expand) (*Dog, error) {
+	r.mu.RLock()
+	d, ok := r.dogs[id]
+	if ok {
+		d = r.copyDog(d, expand)
+	}
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, apperr.NotFoundf("dog %d not found", id)
+	}
+	r.expandBreeder(ctx, d, expand)
+	return d, nil
+}
+
+// InsertDog stores a copy of dog under the next ID and returns the ID
+func (r *MemoryRepository) InsertDog(ctx context.Context, dog *Dog) (int, error) {
+	if err := r.checkBreeder(ctx, dog); err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkBreed(dog); err != nil {
+		return 0, err
+	}
+
+	d := *dog
+	d.ID, d.Breed, d.Breeder = r.nextID, nil, nil
+	r.dogs[d.ID] = &d
+	r.nextID++
+
+	return d.ID, nil
+}
+
+// UpdateDog replaces an existing dog
+func (r *MemoryRepository) UpdateDog(ctx context.Context, dog *Dog) error {
+	if err := r.checkBreeder(ctx, dog); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.dogs[dog.ID]; !ok {
+		return apperr.NotFoundf("dog %d not found", dog.ID)
+	}
+	if err := r.checkBreed(dog); err != nil {
+		return err
+	}
+
+	d := *dog
+	d.Breed, d.Breeder = nil, nil
+	r.dogs[d.ID] = &d
+
+	return nil
+}
+
+// DeleteDog deletes a dog by ID
+func (r *MemoryRepository) DeleteDog(ctx context.Context, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.dogs[id]; !ok {
+		return apperr.NotFoundf("dog %d not found", id)
+	}
+	delete(r.dogs, id)
+
+	return nil
+}
+
+// Snapshot records the repository's contents and returns a function that puts
+// them back, so a unit of work can undo its writes
+func (r *MemoryRepository) Snapshot() (restore func()) {
+	r.mu.RLock()
+	breeds, dogs, nextID := maps.Clone(r.breeds), maps.Clone(r.dogs), r.nextID
+	r.mu.RUnlock()
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.breeds, r.dogs, r.nextID = breeds, dogs, nextID
+	}
+}
+
+// breederDeletions is implemented by breeder repositories that report deletions
+type breederDeletions interface {
+	OnDelete(fn func(id int))
+}
+
+// clearBreeder removes breeder id from every dog that references it. The
+// dogs are replaced rather than changed in place so snapshots stay intact.
+func (r *MemoryRepository) clearBreeder(id int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key, d := range r.dogs {
+		if d.BreederID == id {
+			dog := *d
+			dog.BreederID = 0
+			r.dogs[key] = &dog
+		}
+	}
+}
+
+// copyDog copies d and attaches its breed when expand asks for it.
+// The caller must hold r.mu.
+func (r *MemoryRepository) copyDog(d *Dog, expand list.Expand) *Dog {
+	dog := *d
+	if b, ok := r.breeds[d.BreedID]; ok && expand.Breed {
+		breed := *b
+		dog.Breed = &breed
+	}
+	return &dog
+}
+
+// expandBreeder attaches the breeder summary when expand asks for it
+func (r *MemoryRepository) expandBreeder(ctx context.Context, d *Dog, expand list.Expand) {
+	if !expand.Breeder || d.BreederID == 0 || r.breeders == nil {
+		return
+	}
+	if b, err := r.breeders.GetBreederByID(ctx, d.BreederID); err == nil {
+		d.Breeder = b.Summary()
+	}
+}
+
+// checkBreed enforces the breed_id foreign key. The caller must hold r.mu.
+func (r *MemoryRepository) checkBreed(dog *Dog) error {
+	if _, ok := r.breeds[dog.BreedID]; dog.BreedID != 0 && !ok {
+		return apperr.New(apperr.Validation, "dog references a record that does not exist")
+	}
+	return nil
+}
+
+// checkBreeder enforces the breeder_id foreign key
+func (r *MemoryRepository) checkBreeder(ctx context.Context, dog *Dog) error {
+	if dog.BreederID == 0 || r.breeders == nil {
+		return nil
+	}
+	_, err := r.breeders.GetBreederByID(ctx, dog.BreederID)
+	if apperr.Is(err, apperr.NotFound) {
+		return apperr.New(apperr.Validation, "dog references a record that does not exist")
+	}
+	return err
+}
@@ -0,0 +1,64 @@
+package dog
+
+import (
+	"context"
+	"go-breeders/internal/list"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMemoryRepository_ConcurrentWrites(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository(nil, MockFixtures())
+
+	const writers = 50
+	ids := make(chan int, writers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			id, err := repo.InsertDog(ctx, &Dog{DogName: "Pup", BreedID: 1, Weight: 4, DateOfBirth: time.Now()})
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			ids <- id
+			if _, _, err := repo.AllDogs(ctx, list.Options{Page: 1, PerPage: 10}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	seen := make(map[int]bool)
+	for id := range ids {
+		if seen[id] {
+			t.Errorf("id %d handed out twice", id)
+		}
+		seen[id] = true
+	}
+
+	_, total, _ := repo.AllDogs(ctx, list.All())
+	if want := len(MockFixtures().Dogs) + writers; total != want {
+		t.Errorf("expected %d dogs, got %d", want, total)
+	}
+}
+
+func TestMemoryRepository_ReturnsCopies(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository(nil, MockFixtures())
+
+	d, err := repo.GetDogByID(ctx, 1, list.Expand{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.DogName = "changed"
+
+	if again, _ := repo.GetDogByID(ctx, 1, list.Expand{}); again.DogName == "changed" {
+		t.Error("modifying a returned dog changed the stored one")
+	}
+}
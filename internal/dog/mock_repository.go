@@ -1,6 +1,13 @@
 package dog
 
-import "time"
+import (
+	"context"
+	"go-breeders/internal/apperr"
+	"go-breeders/internal/breeder"
+	"go-breeders/internal/list"
+	"strings"
+	"time"
+)
 
 // MockRepository is a mock implementation for testing
 type MockRepository struct{}
@@ -10,8 +17,20 @@ func NewMockRepository() Repository {
 	return &MockRepository{}
 }
 
-// AllBreeds returns mock dog breed data
-func (m *MockRepository) AllBreeds() ([]*Breed, error) {
+// AllBreeds returns mock dog breed data, filtered, sorted and paged like MySQL
+func (m *MockRepository) AllBreeds(ctx context.Context, opts list.Options) ([]*Breed, int, error) {
+	breeds, total := list.Apply(m.breeds(), opts, keepBreed(opts.Filters), breedSortKey, "breed")
+	return breeds, total, nil
+}
+
+// MockFixtures returns the data MockRepository serves, for seeding a MemoryRepository
+func MockFixtures() Fixtures {
+	m := &MockRepository{}
+	return Fixtures{Breeds: m.breeds(), Dogs: m.dogs()}
+}
+
+// breeds returns the mock dog breed fixtures
+func (m *MockRepository) breeds() []*Breed {
 	return []*Breed{
 		{
 			ID:               1,
@@ -46,22 +65,30 @@ func (m *MockRepository) AllBreeds() ([]*Breed, error) {
 			AlternateNames:   "Lab",
 			GeographicOrigin: "Canada",
 		},
-	}, nil
+	}
 }
 
 // GetBreedByID returns a single mock dog breed
-func (m *MockRepository) GetBreedByID(id int) (*Breed, error) {
-	breeds, _ := m.AllBreeds()
-	for _, breed := range breeds {
+func (m *MockRepository) GetBreedByID(ctx context.Context, id int) (*Breed, error) {
+	for _, breed := range m.breeds() {
 		if breed.ID == id {
 			return breed, nil
 		}
 	}
-	return nil, nil
+	return nil, apperr.NotFoundf("dog breed %d not found", id)
+}
+
+// AllDogs returns mock dog data, filtered, sorted and paged like MySQL
+func (m *MockRepository) AllDogs(ctx context.Context, opts list.Options) ([]*Dog, int, error) {
+	dogs, total := list.Apply(m.dogs(), opts, keepDog(opts.Filters), dogSortKey, "dog_name")
+	for _, d := range dogs {
+		m.expand(ctx, d, opts.Expand)
+	}
+	return dogs, total, nil
 }
 
-// AllDogs returns mock dog data
-func (m *MockRepository) AllDogs() ([]*Dog, error) {
+// dogs returns the mock dog fixtures
+func (m *MockRepository) dogs() []*Dog {
 	return []*Dog{
 		{
 			ID:               1,
@@ -85,31 +112,106 @@ func (m *MockRepository) AllDogs() ([]*Dog, error) {
 			Description:      "Small but mighty Chihuahua",
 			Weight:           5,
 		},
-	}, nil
+	}
 }
 
 // GetDogByID returns a single mock dog
-func (m *MockRepository) GetDogByID(id int) (*Dog, error) {
-	dogs, _ := m.AllDogs()
-	for _, dog := range dogs {
+func (m *MockRepository) GetDogByID(ctx context.Context, id int, expand list.Expand) (*Dog, error) {
+	for _, dog := range m.dogs() {
 		if dog.ID == id {
-			return dog, nil
+			return m.expand(ctx, dog, expand), nil
 		}
 	}
-	return nil, nil
+	return nil, apperr.NotFoundf("dog %d not found", id)
 }
 
 // InsertDog simulates inserting a dog
-func (m *MockRepository) InsertDog(dog *Dog) (int, error) {
+func (m *MockRepository) InsertDog(ctx context.Context, dog *Dog) (int, error) {
 	return 999, nil
 }
 
 // UpdateDog simulates updating a dog
-func (m *MockRepository) UpdateDog(dog *Dog) error {
-	return nil
+func (m *MockRepository) UpdateDog(ctx context.Context, dog *Dog) error {
+	_, err := m.GetDogByID(ctx, dog.ID, list.Expand{})
+	return err
 }
 
 // DeleteDog simulates deleting a dog
-func (m *MockRepository) DeleteDog(id int) error {
-	return nil
+func (m *MockRepository) DeleteDog(ctx context.Context, id int) error {
+	_, err := m.GetDogByID(ctx, id, list.Expand{})
+	return err
+}
+
+// expand fills in the breed and breeder of d the way the MySQL joins would
+func (m *MockRepository) expand(ctx context.Context, d *Dog, expand list.Expand) *Dog {
+	if expand.Breed {
+		if b, err := m.GetBreedByID(ctx, d.BreedID); err == nil {
+			d.Breed = b
+		}
+	}
+	if expand.Breeder {
+		if b, err := breeder.NewMockRepository().GetBreederByID(ctx, d.BreederID); err == nil {
+			d.Breeder = b.Summary()
+		}
+	}
+	return d
+}
+
+// keepBreed returns the list.Apply filter for the breed filters in f
+func keepBreed(f list.Filters) func(*Breed) bool {
+	return func(b *Breed) bool {
+		return (f.Origin == "" || strings.EqualFold(b.GeographicOrigin, f.Origin)) &&
+			(f.MinWeight == 0 || b.WeightHighLbs >= f.MinWeight) &&
+			(f.MaxWeight == 0 || b.WeightLowLbs <= f.MaxWeight) &&
+			(f.MinLifespan == 0 || b.Lifespan >= f.MinLifespan) &&
+			(f.MaxLifespan == 0 || b.Lifespan <= f.MaxLifespan)
+	}
+}
+
+// keepDog returns the list.Apply filter for the dog filters in f
+func keepDog(f list.Filters) func(*Dog) bool {
+	return func(d *Dog) bool {
+		return (f.BreedID == 0 || d.BreedID == f.BreedID) &&
+			(f.BreederID == 0 || d.BreederID == f.BreederID) &&
+			(f.MinWeight == 0 || d.Weight >= f.MinWeight) &&
+			(f.MaxWeight == 0 || d.Weight <= f.MaxWeight)
+	}
+}
+
+// breedSortKey returns the value of a BreedSortFields field for list.Apply
+func breedSortKey(b *Breed, field string) any {
+	switch field {
+	case "breed":
+		return b.Breed
+	case "weight_low_lbs":
+		return b.WeightLowLbs
+	case "weight_high_lbs":
+		return b.WeightHighLbs
+	case "average_weight":
+		return b.AverageWeight
+	case "average_lifespan":
+		return b.Lifespan
+	case "geographic_origin":
+		return b.GeographicOrigin
+	default:
+		return b.ID
+	}
+}
+
+// dogSortKey returns the value of a DogSortFields field for list.Apply
+func dogSortKey(d *Dog, field string) any {
+	switch field {
+	case "dog_name":
+		return d.DogName
+	case "date_of_birth":
+		return int(d.DateOfBirth.Unix())
+	case "weight":
+		return d.Weight
+	case "breed_id":
+		return d.BreedID
+	case "breeder_id":
+		return d.BreederID
+	default:
+		return d.ID
+	}
 }
@@ -1,6 +1,9 @@
 package dog
 
-import "time"
+import (
+	"go-breeders/internal/breeder"
+	"time"
+)
 
 // Breed represents a dog breed
 type Breed struct {
@@ -17,14 +20,15 @@ type Breed struct {
 
 // Dog represents an individual dog
 type Dog struct {
-	ID               int       `json:"id"`
-	DogName          string    `json:"dog_name"`
-	BreedID          int       `json:"breed_id"`
-	BreederID        int       `json:"breeder_id"`
-	Color            string    `json:"color"`
-	DateOfBirth      time.Time `json:"date_of_birth"`
-	SpayedOrNeutered int       `json:"spayed_neutered"`
-	Description      string    `json:"description"`
-	Weight           int       `json:"weight"`
-	Breed            Breed     `json:"breed,omitempty"`
+	ID               int              `json:"id"`
+	DogName          string           `json:"dog_name"`
+	BreedID          int              `json:"breed_id"`
+	BreederID        int              `json:"breeder_id"`
+	Color            string           `json:"color"`
+	DateOfBirth      time.Time        `json:"date_of_birth"`
+	SpayedOrNeutered int              `json:"spayed_neutered"`
+	Description      string           `json:"description"`
+	Weight           int              `json:"weight"`
+	Breed            *Breed           `json:"breed,omitempty"`   // set with ?expand=breed
+	Breeder          *breeder.Summary `json:"breeder,omitempty"` // set with ?expand=breeder
 }
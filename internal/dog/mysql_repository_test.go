@@ -0,0 +1,104 @@
+package dog
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"go-breeders/internal/apperr"
+	"go-breeders/internal/list"
+	"testing"
+	"time"
+)
+
+// blockingDriver is a database/sql driver whose queries never finish on
+// their own; they only return once their context is done. It stands in for
+// a slow MySQL server.
+type blockingDriver struct{}
+
+func (blockingDriver) Open(string) (driver.Conn, error) { return blockingConn{}, nil }
+
+type blockingConn struct{}
+
+func (blockingConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (blockingConn) Close() error                        { return nil }
+func (blockingConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+
+func (blockingConn) QueryContext(ctx context.Context, _ string, _ []driver.NamedValue) (driver.Rows, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (blockingConn) ExecContext(ctx context.Context, _ string, _ []driver.NamedValue) (driver.Result, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func init() {
+	sql.Register("blocking", blockingDriver{})
+}
+
+func TestMySQLRepository_ContextCancellation(t *testing.T) {
+	db, err := sql.Open("blocking", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	tests := []struct {
+		name          string
+		queryTimeout  time.Duration
+		cancelAfter   time.Duration
+		expectedCause error
+	}{
+		{"caller cancels", time.Minute, 20 * time.Millisecond, context.Canceled},
+		{"query deadline", 20 * time.Millisecond, 0, context.DeadlineExceeded},
+	}
+
+	calls := map[string]func(r Repository, ctx context.Context) error{
+		"AllBreeds": func(r Repository, ctx context.Context) error {
+			_, _, err := r.AllBreeds(ctx, list.Options{})
+			return err
+		},
+		"GetDogByID": func(r Repository, ctx context.Context) error {
+			_, err := r.GetDogByID(ctx, 1, list.Expand{Breed: true})
+			return err
+		},
+		"InsertDog": func(r Repository, ctx context.Context) error {
+			_, err := r.InsertDog(ctx, &Dog{DogName: "Rex"})
+			return err
+		},
+		"DeleteDog": func(r Repository, ctx context.Context) error {
+			return r.DeleteDog(ctx, 1)
+		},
+	}
+
+	for _, tt := range tests {
+		for name, call := range calls {
+			t.Run(tt.name+"/"+name, func(t *testing.T) {
+				repo := NewMySQLRepository(db, tt.queryTimeout)
+
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				if tt.cancelAfter > 0 {
+					time.AfterFunc(tt.cancelAfter, cancel)
+				}
+
+				done := make(chan error, 1)
+				go func() { done <- call(repo, ctx) }()
+
+				select {
+				case err := <-done:
+					if !errors.Is(err, tt.expectedCause) {
+						t.Errorf("wrong error: got %v want %v", err, tt.expectedCause)
+					}
+					if !apperr.Is(err, apperr.Unavailable) {
+						t.Errorf("wrong kind: got %v want %v", apperr.KindOf(err), apperr.Unavailable)
+					}
+				case <-time.After(2 * time.Second):
+					t.Fatal("query was not aborted")
+				}
+			})
+		}
+	}
+}
@@ -1,16 +1,27 @@
 package dog
 
+import (
+	"context"
+	"go-breeders/internal/list"
+)
+
+// BreedSortFields are the JSON fields AllBreeds can be sorted by
+var BreedSortFields = []string{"id", "breed", "weight_low_lbs", "weight_high_lbs", "average_weight", "average_lifespan", "geographic_origin"}
+
+// DogSortFields are the JSON fields AllDogs can be sorted by
+var DogSortFields = []string{"id", "dog_name", "date_of_birth", "weight", "breed_id", "breeder_id"}
+
 // Repository defines the interface for dog data operations
 // All implementations (MySQL, MongoDB, Mock) must implement this
 type Repository interface {
 	// Breed operations
-	AllBreeds() ([]*Breed, error)
-	GetBreedByID(id int) (*Breed, error)
+	AllBreeds(ctx context.Context, opts list.Options) ([]*Breed, int, error)
+	GetBreedByID(ctx context.Context, id int) (*Breed, error)
 
 	// Dog operations
-	AllDogs() ([]*Dog, error)
-	GetDogByID(id int) (*Dog, error)
-	InsertDog(dog *Dog) (int, error)
-	UpdateDog(dog *Dog) error
-	DeleteDog(id int) error
+	AllDogs(ctx context.Context, opts list.Options) ([]*Dog, int, error)
+	GetDogByID(ctx context.Context, id int, expand list.Expand) (*Dog, error)
+	InsertDog(ctx context.Context, dog *Dog) (int, error)
+	UpdateDog(ctx context.Context, dog *Dog) error
+	DeleteDog(ctx context.Context, id int) error
 }
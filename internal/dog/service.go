@@ -1,54 +1,62 @@
 package dog
 
+import (
+	"context"
+	"go-breeders/internal/list"
+)
+
 // Service provides business logic for dog operations
 // This is where you put validation, transformations, complex logic
 type Service struct {
-	repo Repository
+	repo     Repository
+	breeders BreederFinder
 }
 
-// NewService creates a new dog service
-func NewService(repo Repository) *Service {
-	return &Service{repo: repo}
+// NewService creates a new dog service; breeders is used to validate breeder IDs
+func NewService(repo Repository, breeders BreederFinder) *Service {
+	return &Service{repo: repo, breeders: breeders}
 }
 
-// GetAllBreeds returns all dog breeds
-// Business logic can be added here (filtering, sorting, etc.)
-func (s *Service) GetAllBreeds() ([]*Breed, error) {
-	return s.repo.AllBreeds()
+// GetAllBreeds returns one page of dog breeds and the total number of matches
+func (s *Service) GetAllBreeds(ctx context.Context, opts list.Options) ([]*Breed, int, error) {
+	return s.repo.AllBreeds(ctx, opts)
 }
 
 // GetBreedByID returns a specific dog breed
-func (s *Service) GetBreedByID(id int) (*Breed, error) {
-	return s.repo.GetBreedByID(id)
+func (s *Service) GetBreedByID(ctx context.Context, id int) (*Breed, error) {
+	return s.repo.GetBreedByID(ctx, id)
 }
 
-// GetAllDogs returns all dogs
-func (s *Service) GetAllDogs() ([]*Dog, error) {
-	return s.repo.AllDogs()
+// GetAllDogs returns one page of dogs and the total number of matches
+func (s *Service) GetAllDogs(ctx context.Context, opts list.Options) ([]*Dog, int, error) {
+	return s.repo.AllDogs(ctx, opts)
 }
 
-// GetDogByID returns a specific dog
-func (s *Service) GetDogByID(id int) (*Dog, error) {
-	return s.repo.GetDogByID(id)
+// GetDogByID returns a specific dog with the related records in expand
+func (s *Service) GetDogByID(ctx context.Context, id int, expand list.Expand) (*Dog, error) {
+	return s.repo.GetDogByID(ctx, id, expand)
 }
 
-// CreateDog creates a new dog
-// Here you can add validation before inserting
-func (s *Service) CreateDog(dog *Dog) (int, error) {
-	// Example: Add validation here
-	// if dog.DogName == "" {
-	//     return 0, errors.New("dog name is required")
-	// }
-
-	return s.repo.InsertDog(dog)
+// CreateDog validates and creates a new dog
+func (s *Service) CreateDog(ctx context.Context, dog *Dog) (int, error) {
+	if err := s.validateDog(ctx, dog); err != nil {
+		return 0, err
+	}
+	return s.repo.InsertDog(ctx, dog)
 }
 
-// UpdateDog updates an existing dog
-func (s *Service) UpdateDog(dog *Dog) error {
-	return s.repo.UpdateDog(dog)
+// UpdateDog validates and updates an existing dog
+func (s *Service) UpdateDog(ctx context.Context, dog *Dog) error {
+	if _, err := s.repo.GetDogByID(ctx, dog.ID, list.Expand{}); err != nil {
+		return err
+	}
+	if err := s.validateDog(ctx, dog); err != nil {
+		return err
+	}
+	return s.repo.UpdateDog(ctx, dog)
 }
 
-// DeleteDog deletes a dog
-func (s *Service) DeleteDog(id int) error {
-	return s.repo.DeleteDog(id)
+// DeleteDog deletes a dog; the repository reports NotFound for a missing ID
+func (s *Service) DeleteDog(ctx context.Context, id int) error {
+	return s.repo.DeleteDog(ctx, id)
 }
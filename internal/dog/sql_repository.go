@@ -0,0 +1,342 @@
+package dog
+
+import (
+	"context"
+	"go-breeders/internal/apperr"
+	"go-breeders/internal/breeder"
+	"go-breeders/internal/database"
+	"go-breeders/internal/list"
+	"time"
+)
+
+// defaultQueryTimeout bounds each query when no timeout is configured
+const defaultQueryTimeout = 3 * time.Second
+
+// SQLRepository is the implementation of Repository for MySQL, PostgreSQL
+// and SQLite. Queries are written once; Dialect covers where they differ.
+type SQLRepository struct {
+	DB           database.DBTX    // a *sql.DB, or a *sql.Tx inside a unit of work
+	QueryTimeout time.Duration    // per-query deadline, on top of the caller's context
+	Dialect      database.Dialect // the SQL flavour of DB's driver
+}
+
+// NewMySQLRepository creates a new MySQL repository for dogs.
+// queryTimeout bounds each query; zero uses the 3 second default.
+func NewMySQLRepository(db database.DBTX, queryTimeout time.Duration) Repository {
+	return newSQLRepository(db, queryTimeout, database.MySQLDialect)
+}
+
+// NewPostgresRepository creates a new PostgreSQL repository for dogs
+func NewPostgresRepository(db database.DBTX, queryTimeout time.Duration) Repository {
+	return newSQLRepository(db, queryTimeout, database.PostgresDialect)
+}
+
+// NewSQLiteRepository creates a new SQLite repository for dogs
+func NewSQLiteRepository(db database.DBTX, queryTimeout time.Duration) Repository {
+	return newSQLRepository(db, queryTimeout, database.SQLiteDialect)
+}
+
+// newSQLRepository creates a repository whose queries are written for dialect
+func newSQLRepository(db database.DBTX, queryTimeout time.Duration, dialect database.Dialect) *SQLRepository {
+	if queryTimeout <= 0 {
+		queryTimeout = defaultQueryTimeout
+	}
+	return &SQLRepository{DB: db, QueryTimeout: queryTimeout, Dialect: dialect}
+}
+
+// withTimeout derives the context for one query. It is cancelled when the
+// caller's context is (client disconnect, server timeout) or after QueryTimeout.
+func (r *SQLRepository) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, r.QueryTimeout)
+}
+
+// AllBreeds returns one page of dog breeds and the total number of matches
+func (r *SQLRepository) AllBreeds(ctx context.Context, opts list.Options) ([]*Breed, int, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	where := breedWhere(opts.Filters)
+
+	var total int
+	countQuery := r.Dialect.Rebind(`SELECT COUNT(*) FROM dog_breeds` + where.SQL())
+	if err := r.DB.QueryRowContext(ctx, countQuery, where.Args()...).Scan(&total); err != nil {
+		return nil, 0, apperr.FromSQL(err, "dog breed")
+	}
+
+	query := r.Dialect.Rebind(r.breedSelect() + where.SQL() +
+		list.OrderBy(opts, breedSortColumns, "breed") + ` LIMIT ? OFFSET ?`)
+
+	rows, err := r.DB.QueryContext(ctx, query, append(where.Args(), opts.Limit(), opts.Offset())...)
+	if err != nil {
+		return nil, 0, apperr.FromSQL(err, "dog breed")
+	}
+	defer rows.Close()
+
+	var breeds []*Breed
+	for rows.Next() {
+		var b Breed
+		err := rows.Scan(
+			&b.ID, &b.Breed, &b.WeightLowLbs, &b.WeightHighLbs,
+			&b.AverageWeight, &b.Lifespan, &b.Details,
+			&b.AlternateNames, &b.GeographicOrigin,
+		)
+		if err != nil {
+			return nil, 0, apperr.FromSQL(err, "dog breed")
+		}
+		breeds = append(breeds, &b)
+	}
+
+	return breeds, total, apperr.FromSQL(rows.Err(), "dog breed")
+}
+
+// GetBreedByID returns a single dog breed by ID
+func (r *SQLRepository) GetBreedByID(ctx context.Context, id int) (*Breed, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := r.Dialect.Rebind(r.breedSelect() + ` WHERE id = ?`)
+
+	var breed Breed
+	err := r.DB.QueryRowContext(ctx, query, id).Scan(
+		&breed.ID, &breed.Breed, &breed.WeightLowLbs, &breed.WeightHighLbs,
+		&breed.AverageWeight, &breed.Lifespan, &breed.Details,
+		&breed.AlternateNames, &breed.GeographicOrigin,
+	)
+	if err != nil {
+		return nil, apperr.FromSQL(err, "dog breed")
+	}
+
+	return &breed, nil
+}
+
+// AllDogs returns one page of dogs and the total number of matches.
+// opts.Expand joins in the breed and breeder in the same query.
+func (r *SQLRepository) AllDogs(ctx context.Context, opts list.Options) ([]*Dog, int, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	where := dogWhere(opts.Filters)
+
+	var total int
+	countQuery := r.Dialect.Rebind(`SELECT COUNT(*) FROM dogs d` + where.SQL())
+	if err := r.DB.QueryRowContext(ctx, countQuery, where.Args()...).Scan(&total); err != nil {
+		return nil, 0, apperr.FromSQL(err, "dog")
+	}
+
+	query := r.Dialect.Rebind(r.dogSelect(opts.Expand) + where.SQL() +
+		list.OrderBy(opts, dogSortColumns, "d.dog_name") + ` LIMIT ? OFFSET ?`)
+
+	rows, err := r.DB.QueryContext(ctx, query, append(where.Args(), opts.Limit(), opts.Offset())...)
+	if err != nil {
+		return nil, 0, apperr.FromSQL(err, "dog")
+	}
+	defer rows.Close()
+
+	var dogs []*Dog
+	for rows.Next() {
+		d, err := scanDog(rows, opts.Expand)
+		if err != nil {
+			return nil, 0, apperr.FromSQL(err, "dog")
+		}
+		dogs = append(dogs, d)
+	}
+
+	return dogs, total, apperr.FromSQL(rows.Err(), "dog")
+}
+
+// GetDogByID returns a single dog by ID, joining in the related records in expand
+func (r *SQLRepository) GetDogByID(ctx context.Context, id int, expand list.Expand) (*Dog, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := r.Dialect.Rebind(r.dogSelect(expand) + ` WHERE d.id = ?`)
+
+	dog, err := scanDog(r.DB.QueryRowContext(ctx, query, id), expand)
+	if err != nil {
+		return nil, apperr.FromSQL(err, "dog")
+	}
+
+	return dog, nil
+}
+
+// InsertDog inserts a new dog and returns the ID.
+// A zero breed or breeder ID is stored as NULL.
+func (r *SQLRepository) InsertDog(ctx context.Context, dog *Dog) (int, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `INSERT INTO dogs (dog_name, breed_id, breeder_id, color,
+			date_of_birth, spayed_neutered, description, weight)
+			VALUES (?, NULLIF(?, 0), NULLIF(?, 0), ?, ?, ?, ?, ?)`
+
+	id, err := r.Dialect.Insert(ctx, r.DB, query,
+		dog.DogName, dog.BreedID, dog.BreederID, dog.Color,
+		dog.DateOfBirth, dog.SpayedOrNeutered, dog.Description, dog.Weight,
+	)
+	if err != nil {
+		return 0, apperr.FromSQL(err, "dog")
+	}
+
+	return id, nil
+}
+
+// UpdateDog updates an existing dog, or returns NotFound
+func (r *SQLRepository) UpdateDog(ctx context.Context, dog *Dog) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `UPDATE dogs SET dog_name = ?, breed_id = NULLIF(?, 0),
+			breeder_id = NULLIF(?, 0), color = ?, date_of_birth = ?,
+			spayed_neutered = ?, description = ?, weight = ? WHERE id = ?`
+
+	result, err := r.DB.ExecContext(ctx, r.Dialect.Rebind(query),
+		dog.DogName, dog.BreedID, dog.BreederID, dog.Color,
+		dog.DateOfBirth, dog.SpayedOrNeutered, dog.Description,
+		dog.Weight, dog.ID,
+	)
+
+	return apperr.FromResult(result, err, "dog")
+}
+
+// DeleteDog deletes a dog by ID, or returns NotFound
+func (r *SQLRepository) DeleteDog(ctx context.Context, id int) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := r.Dialect.Rebind(`DELETE FROM dogs WHERE id = ?`)
+	result, err := r.DB.ExecContext(ctx, query, id)
+	return apperr.FromResult(result, err, "dog")
+}
+
+// breedSortColumns maps BreedSortFields to SQL expressions
+var breedSortColumns = map[string]string{
+	"id":                "id",
+	"breed":             "breed",
+	"weight_low_lbs":    "weight_low_lbs",
+	"weight_high_lbs":   "weight_high_lbs",
+	"average_weight":    "weight_low_lbs + weight_high_lbs", // same order as the average, without integer division
+	"average_lifespan":  "lifespan",
+	"geographic_origin": "geographic_origin",
+}
+
+// dogSortColumns maps DogSortFields to SQL columns
+var dogSortColumns = map[string]string{
+	"id":            "d.id",
+	"dog_name":      "d.dog_name",
+	"date_of_birth": "d.date_of_birth",
+	"weight":        "d.weight",
+	"breed_id":      "d.breed_id",
+	"breeder_id":    "d.breeder_id",
+}
+
+// breedWhere builds the WHERE clause for the breed filters in f. Text filters compare
+// lowercased, since only MySQL's collation ignores case.
+func breedWhere(f list.Filters) *list.Where {
+	var where list.Where
+	if f.Origin != "" {
+		where.Add("LOWER(geographic_origin) = LOWER(?)", f.Origin)
+	}
+	if f.MinWeight > 0 {
+		where.Add("weight_high_lbs >= ?", f.MinWeight)
+	}
+	if f.MaxWeight > 0 {
+		where.Add("weight_low_lbs <= ?", f.MaxWeight)
+	}
+	if f.MinLifespan > 0 {
+		where.Add("lifespan >= ?", f.MinLifespan)
+	}
+	if f.MaxLifespan > 0 {
+		where.Add("lifespan <= ?", f.MaxLifespan)
+	}
+	return &where
+}
+
+// dogWhere builds the WHERE clause for the dog filters in f
+func dogWhere(f list.Filters) *list.Where {
+	var where list.Where
+	if f.BreedID > 0 {
+		where.Add("d.breed_id = ?", f.BreedID)
+	}
+	if f.BreederID > 0 {
+		where.Add("d.breeder_id = ?", f.BreederID)
+	}
+	if f.MinWeight > 0 {
+		where.Add("d.weight >= ?", f.MinWeight)
+	}
+	if f.MaxWeight > 0 {
+		where.Add("d.weight <= ?", f.MaxWeight)
+	}
+	return &where
+}
+
+// breedSelect returns the SELECT for dog breeds; rows scan in Breed field order
+func (r *SQLRepository) breedSelect() string {
+	return `SELECT id, breed, weight_low_lbs, weight_high_lbs, ` +
+		r.Dialect.RoundInt("(weight_low_lbs + weight_high_lbs) / 2.0") + ` AS average_weight,
+			lifespan, COALESCE(details, ''),
+			COALESCE(alternate_names, ''), COALESCE(geographic_origin, '')
+			FROM dog_breeds`
+}
+
+// dogSelect returns the SELECT for dogs (aliased d) with the breed (b) and
+// breeder (br) LEFT JOINed when expand asks for them, so listing dogs with
+// their relations costs one query rather than one per dog
+func (r *SQLRepository) dogSelect(expand list.Expand) string {
+	columns := `d.id, d.dog_name, COALESCE(d.breed_id, 0), COALESCE(d.breeder_id, 0),
+			d.color, d.date_of_birth, d.spayed_neutered, d.description, d.weight`
+	joins := ""
+
+	if expand.Breed {
+		columns += `, COALESCE(b.id, 0), COALESCE(b.breed, ''),
+			COALESCE(b.weight_low_lbs, 0), COALESCE(b.weight_high_lbs, 0),
+			COALESCE(` + r.Dialect.RoundInt("(b.weight_low_lbs + b.weight_high_lbs) / 2.0") + `, 0),
+			COALESCE(b.lifespan, 0), COALESCE(b.details, ''),
+			COALESCE(b.alternate_names, ''), COALESCE(b.geographic_origin, '')`
+		joins += ` LEFT JOIN dog_breeds b ON b.id = d.breed_id`
+	}
+
+	if expand.Breeder {
+		columns += `, COALESCE(br.id, 0), COALESCE(br.breeder_name, ''),
+			COALESCE(br.city, ''), COALESCE(br.prov_state, ''), COALESCE(br.country, '')`
+		joins += ` LEFT JOIN breeders br ON br.id = d.breeder_id`
+	}
+
+	return `SELECT ` + columns + ` FROM dogs d` + joins
+}
+
+// scanDog scans a row produced by dogSelect with the same expand
+func scanDog(row interface{ Scan(...any) error }, expand list.Expand) (*Dog, error) {
+	var d Dog
+	var b Breed
+	var br breeder.Summary
+
+	dest := []any{
+		&d.ID, &d.DogName, &d.BreedID, &d.BreederID,
+		&d.Color, &d.DateOfBirth, &d.SpayedOrNeutered,
+		&d.Description, &d.Weight,
+	}
+	if expand.Breed {
+		dest = append(dest,
+			&b.ID, &b.Breed, &b.WeightLowLbs, &b.WeightHighLbs,
+			&b.AverageWeight, &b.Lifespan, &b.Details,
+			&b.AlternateNames, &b.GeographicOrigin,
+		)
+	}
+	if expand.Breeder {
+		dest = append(dest, &br.ID, &br.BreederName, &br.City, &br.ProvState, &br.Country)
+	}
+
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	// a zero ID means the LEFT JOIN found nothing (the reference is NULL)
+	if b.ID != 0 {
+		d.Breed = &b
+	}
+	if br.ID != 0 {
+		d.Breeder = &br
+	}
+
+	return &d, nil
+}
@@ -0,0 +1,55 @@
+package dog
+
+import (
+	"context"
+	"go-breeders/internal/apperr"
+	"go-breeders/internal/breeder"
+	"go-breeders/internal/validate"
+)
+
+// weightTolerance is how far outside its breed's weight range a dog may be
+// (as a fraction of the bounds) before the weight is rejected
+const weightTolerance = 0.25
+
+// BreederFinder is the part of breeder.Repository the dog service needs to
+// confirm that a dog's breeder exists
+type BreederFinder interface {
+	GetBreederByID(ctx context.Context, id int) (*breeder.Breeder, error)
+}
+
+// validateDog checks dog against the field rules and confirms that the
+// referenced breed and breeder exist
+func (s *Service) validateDog(ctx context.Context, dog *Dog) error {
+	v := validate.New()
+
+	v.Required("dog_name", dog.DogName)
+	v.MaxLength("dog_name", dog.DogName, 255)
+	v.MaxLength("color", dog.Color, 255)
+	v.Past("date_of_birth", dog.DateOfBirth)
+	v.Check(dog.Weight > 0, "weight", "must be greater than zero")
+	v.OneOf("spayed_neutered", dog.SpayedOrNeutered, 0, 1)
+
+	if dog.BreedID != 0 {
+		breed, err := s.repo.GetBreedByID(ctx, dog.BreedID)
+		switch {
+		case apperr.Is(err, apperr.NotFound):
+			v.Check(false, "breed_id", "does not exist")
+		case err != nil:
+			return err
+		case dog.Weight > 0:
+			v.WithinTolerance("weight", dog.Weight, breed.WeightLowLbs, breed.WeightHighLbs, weightTolerance)
+		}
+	}
+
+	if dog.BreederID != 0 && s.breeders != nil {
+		_, err := s.breeders.GetBreederByID(ctx, dog.BreederID)
+		switch {
+		case apperr.Is(err, apperr.NotFound):
+			v.Check(false, "breeder_id", "does not exist")
+		case err != nil:
+			return err
+		}
+	}
+
+	return v.Err()
+}
@@ -0,0 +1,195 @@
+package list
+
+import (
+	"fmt"
+	"go-breeders/internal/apperr"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultPerPage is the page size used when ?per_page= is not given
+	DefaultPerPage = 25
+	// MaxPerPage caps ?per_page= so a single request cannot pull a whole table
+	MaxPerPage = 100
+)
+
+// Filters narrows list results. Each repository applies the fields that make
+// sense for its resource and ignores the rest.
+type Filters struct {
+	Origin      string // breeds: geographic origin, case-insensitive
+	MinWeight   int    // breeds: range overlaps; animals: weight at least
+	MaxWeight   int    // breeds: range overlaps; animals: weight at most
+	MinLifespan int    // breeds
+	MaxLifespan int    // breeds
+	BreedID     int    // animals
+	BreederID   int    // animals
+	City        string // breeders, case-insensitive
+	ProvState   string // breeders, case-insensitive
+	Country     string // breeders, case-insensitive
+	Active      *int   // breeders
+}
+
+// Expand selects related records to embed in dog and cat responses
+type Expand struct {
+	Breed   bool
+	Breeder bool
+}
+
+// ParseExpand reads ?expand=breed,breeder from r
+func ParseExpand(r *http.Request) (Expand, error) {
+	var expand Expand
+	raw := r.URL.Query().Get("expand")
+	if raw == "" {
+		return expand, nil
+	}
+
+	for _, name := range strings.Split(raw, ",") {
+		switch strings.TrimSpace(name) {
+		case "breed":
+			expand.Breed = true
+		case "breeder":
+			expand.Breeder = true
+		default:
+			return Expand{}, apperr.New(apperr.BadRequest,
+				fmt.Sprintf("cannot expand %q; allowed: breed, breeder", name))
+		}
+	}
+	return expand, nil
+}
+
+// Options describes which page of a list to return and in what order
+type Options struct {
+	Page    int
+	PerPage int
+	Sort    string // JSON field name; empty means the repository default
+	Desc    bool
+	Filters Filters
+	Expand  Expand
+}
+
+// All returns options that select every row in a single page, for internal
+// callers such as search that need the whole (small) table
+func All() Options {
+	return Options{Page: 1, PerPage: math.MaxInt32}
+}
+
+// Limit returns the SQL LIMIT for the options
+func (o Options) Limit() int {
+	if o.PerPage < 1 {
+		return DefaultPerPage
+	}
+	return o.PerPage
+}
+
+// Offset returns the SQL OFFSET for the options
+func (o Options) Offset() int {
+	if o.Page < 1 {
+		return 0
+	}
+	return (o.Page - 1) * o.Limit()
+}
+
+// Direction returns ASC or DESC for the options
+func (o Options) Direction() string {
+	if o.Desc {
+		return "DESC"
+	}
+	return "ASC"
+}
+
+// Parse reads ?page=, ?per_page=, ?sort= and the filter params from r.
+// sortable lists the JSON field names the resource can be sorted by; a
+// leading "-" on the sort value sorts descending.
+func Parse(r *http.Request, sortable []string) (Options, error) {
+	q := r.URL.Query()
+	opts := Options{Page: 1, PerPage: DefaultPerPage}
+
+	var err error
+	intParam := func(name string, lowest int) int {
+		raw := q.Get(name)
+		if raw == "" || err != nil {
+			return 0
+		}
+		n, convErr := strconv.Atoi(raw)
+		if convErr != nil || n < lowest {
+			err = apperr.New(apperr.BadRequest, fmt.Sprintf("invalid %s", name))
+		}
+		return n
+	}
+
+	if page := intParam("page", 1); page > 0 {
+		opts.Page = page
+	}
+	if perPage := intParam("per_page", 1); perPage > 0 {
+		opts.PerPage = min(perPage, MaxPerPage)
+	}
+
+	opts.Filters = Filters{
+		Origin:      q.Get("origin"),
+		MinWeight:   intParam("min_weight", 0),
+		MaxWeight:   intParam("max_weight", 0),
+		MinLifespan: intParam("min_lifespan", 0),
+		MaxLifespan: intParam("max_lifespan", 0),
+		BreedID:     intParam("breed_id", 1),
+		BreederID:   intParam("breeder_id", 1),
+		City:        q.Get("city"),
+		ProvState:   q.Get("prov_state"),
+		Country:     q.Get("country"),
+	}
+	if q.Get("active") != "" {
+		active := intParam("active", 0)
+		opts.Filters.Active = &active
+	}
+	if err != nil {
+		return Options{}, err
+	}
+
+	if opts.Expand, err = ParseExpand(r); err != nil {
+		return Options{}, err
+	}
+
+	if sort := q.Get("sort"); sort != "" {
+		opts.Sort, opts.Desc = strings.TrimPrefix(sort, "-"), strings.HasPrefix(sort, "-")
+		if !slices.Contains(sortable, opts.Sort) {
+			return Options{}, apperr.New(apperr.BadRequest,
+				fmt.Sprintf("cannot sort by %q; allowed: %s", opts.Sort, strings.Join(sortable, ", ")))
+		}
+	}
+
+	return opts, nil
+}
+
+// Meta is the pagination metadata sent alongside a page of results
+type Meta struct {
+	Page       int `json:"page"`
+	PerPage    int `json:"per_page"`
+	Total      int `json:"total"`
+	TotalPages int `json:"total_pages"`
+}
+
+// Page is the JSON body returned by list endpoints
+type Page[T any] struct {
+	Data []T  `json:"data"`
+	Meta Meta `json:"meta"`
+}
+
+// NewPage wraps one page of items with metadata computed from total
+func NewPage[T any](items []T, total int, opts Options) Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+	perPage := opts.Limit()
+	return Page[T]{
+		Data: items,
+		Meta: Meta{
+			Page:       max(opts.Page, 1),
+			PerPage:    perPage,
+			Total:      total,
+			TotalPages: (total + perPage - 1) / perPage,
+		},
+	}
+}
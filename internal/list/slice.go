@@ -0,0 +1,59 @@
+package list
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+)
+
+// Apply filters, sorts and pages an in-memory slice the same way the SQL
+// repositories do, returning the page and the total number of matches.
+// keep reports whether an item passes the filters; key returns the value
+// (int or string) of the given sort field, or of fallback when none is set.
+func Apply[T any](items []T, opts Options, keep func(T) bool, key func(T, string) any, fallback string) ([]T, int) {
+	var matched []T
+	for _, item := range items {
+		if keep(item) {
+			matched = append(matched, item)
+		}
+	}
+
+	field := opts.Sort
+	if field == "" {
+		field = fallback
+	}
+	slices.SortStableFunc(matched, func(a, b T) int {
+		c := compare(key(a, field), key(b, field))
+		if opts.Desc {
+			return -c
+		}
+		return c
+	})
+
+	total := len(matched)
+	start := min(opts.Offset(), total)
+	end := min(start+opts.Limit(), total)
+	return matched[start:end], total
+}
+
+// ByID returns the values of an in-memory table keyed by ID in ID order, so
+// list.Apply's stable sort breaks ties by ID like the SQL repositories do
+func ByID[T any](m map[int]T) []T {
+	out := make([]T, 0, len(m))
+	for _, id := range slices.Sorted(maps.Keys(m)) {
+		out = append(out, m[id])
+	}
+	return out
+}
+
+// compare orders two sort keys of the same type
+func compare(a, b any) int {
+	switch a := a.(type) {
+	case int:
+		return cmp.Compare(a, b.(int))
+	case string:
+		return cmp.Compare(a, b.(string))
+	default:
+		return 0
+	}
+}
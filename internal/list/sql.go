@@ -0,0 +1,44 @@
+package list
+
+import "strings"
+
+// Where accumulates AND-ed SQL conditions and their arguments
+type Where struct {
+	clauses []string
+	args    []any
+}
+
+// Add appends a condition such as "breed_id = ?" with its arguments
+func (w *Where) Add(clause string, args ...any) {
+	w.clauses = append(w.clauses, clause)
+	w.args = append(w.args, args...)
+}
+
+// SQL returns the WHERE clause, or an empty string when there are no conditions
+func (w *Where) SQL() string {
+	if len(w.clauses) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(w.clauses, " AND ")
+}
+
+// Args returns the arguments for the placeholders in SQL
+func (w *Where) Args() []any {
+	return w.args
+}
+
+// OrderBy returns an ORDER BY clause for opts. columns maps JSON sort fields
+// to SQL expressions; fallback is used when opts.Sort is empty. The "id"
+// column (columns["id"], so joined queries can qualify it) is always
+// appended as a tie-breaker so pages are stable.
+func OrderBy(opts Options, columns map[string]string, fallback string) string {
+	column, ok := columns[opts.Sort]
+	if !ok {
+		column = fallback
+	}
+	id, ok := columns["id"]
+	if !ok {
+		id = "id"
+	}
+	return " ORDER BY " + column + " " + opts.Direction() + ", " + id + " " + opts.Direction()
+}
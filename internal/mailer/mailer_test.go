@@ -0,0 +1,49 @@
+package mailer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFile(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	f := &File{Dir: dir, From: "Go Breeders <noreply@example.com>"}
+
+	msg := Message{To: "admin@example.com", Subject: "Réinitialiser", Body: "line one\nline two"}
+	if err := f.Send(context.Background(), msg); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Send(context.Background(), msg); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if err != nil || len(files) != 2 {
+		t.Fatalf("expected 2 files, got %v, %v", files, err)
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"From: Go Breeders <noreply@example.com>\r\n",
+		"To: admin@example.com\r\n",
+		"Subject: =?utf-8?q?R=C3=A9initialiser?=\r\n",
+		"\r\n\r\nline one\r\nline two",
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected message to contain %q, got %q", want, data)
+		}
+	}
+}
+
+func TestFormat_HeaderInjection(t *testing.T) {
+	msg := Message{To: "admin@example.com\r\nBcc: victim@example.com", Subject: "hi"}
+	if _, err := format("noreply@example.com", msg, time.Time{}); err == nil {
+		t.Error("expected a line break in a header to be rejected")
+	}
+}
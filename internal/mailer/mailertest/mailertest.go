@@ -0,0 +1,39 @@
+// Package mailertest provides a mailer.Sender that keeps messages for tests.
+package mailertest
+
+import (
+	"context"
+	"go-breeders/internal/mailer"
+	"sync"
+)
+
+// Outbox records every message sent through it
+type Outbox struct {
+	mu       sync.Mutex
+	messages []mailer.Message
+}
+
+// Send records msg
+func (o *Outbox) Send(ctx context.Context, msg mailer.Message) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.messages = append(o.messages, msg)
+	return nil
+}
+
+// Messages returns the messages sent so far, oldest first
+func (o *Outbox) Messages() []mailer.Message {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]mailer.Message(nil), o.messages...)
+}
+
+// Last returns the most recent message, or false when none was sent
+func (o *Outbox) Last() (mailer.Message, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(o.messages) == 0 {
+		return mailer.Message{}, false
+	}
+	return o.messages[len(o.messages)-1], true
+}
@@ -0,0 +1,121 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"go-breeders/internal/database"
+)
+
+// lockName is the advisory lock held while migrations run; PostgreSQL
+// advisory locks are keyed by number, so it uses lockKey instead.
+const (
+	lockName = "go-breeders:migrate"
+	lockKey  = 4187302
+)
+
+// Dialect holds the database-specific parts of running migrations.
+type Dialect struct {
+	// Name is the directory under migrations/ holding this dialect's scripts.
+	Name string
+	// CreateTable creates schema_migrations if it does not exist.
+	CreateTable string
+	// Rebind rewrites "?" placeholders for the driver; nil leaves them as they are.
+	Rebind func(query string) string
+	// Lock blocks concurrent runs; Unlock releases it on the same connection.
+	// Unlock is given the run's error so a transactional lock can roll back.
+	Lock   func(ctx context.Context, conn *sql.Conn) error
+	Unlock func(ctx context.Context, conn *sql.Conn, runErr error) error
+}
+
+// MySQL is the dialect for MySQL and MariaDB, locking with GET_LOCK.
+var MySQL = Dialect{
+	Name: "mysql",
+	CreateTable: `create table if not exists schema_migrations (
+		version bigint not null primary key,
+		name varchar(255) not null,
+		dirty boolean not null default false,
+		applied_at datetime not null
+	)`,
+	Lock: func(ctx context.Context, conn *sql.Conn) error {
+		var got sql.NullInt64
+		if err := conn.QueryRowContext(ctx, `select get_lock(?, 10)`, lockName).Scan(&got); err != nil {
+			return err
+		}
+		if got.Int64 != 1 {
+			return errors.New("migrate: another migration run holds the lock")
+		}
+		return nil
+	},
+	Unlock: func(ctx context.Context, conn *sql.Conn, _ error) error {
+		_, err := conn.ExecContext(ctx, `select release_lock(?)`, lockName)
+		return err
+	},
+}
+
+// Postgres is the dialect for PostgreSQL, locking with pg_advisory_lock.
+var Postgres = Dialect{
+	Name: "postgres",
+	CreateTable: `create table if not exists schema_migrations (
+		version bigint not null primary key,
+		name varchar(255) not null,
+		dirty boolean not null default false,
+		applied_at timestamptz not null
+	)`,
+	Rebind: database.Rebind,
+	Lock: func(ctx context.Context, conn *sql.Conn) error {
+		_, err := conn.ExecContext(ctx, `select pg_advisory_lock($1)`, lockKey)
+		return err
+	},
+	Unlock: func(ctx context.Context, conn *sql.Conn, _ error) error {
+		_, err := conn.ExecContext(ctx, `select pg_advisory_unlock($1)`, lockKey)
+		return err
+	},
+}
+
+// SQLite is the dialect for SQLite. SQLite has no advisory locks, so a run
+// holds a write transaction instead, which also makes each run atomic: a
+// failed run is rolled back and leaves no dirty flag behind.
+var SQLite = Dialect{
+	Name: "sqlite",
+	CreateTable: `create table if not exists schema_migrations (
+		version integer not null primary key,
+		name varchar(255) not null,
+		dirty boolean not null default false,
+		applied_at datetime not null
+	)`,
+	Lock: func(ctx context.Context, conn *sql.Conn) error {
+		_, err := conn.ExecContext(ctx, `begin immediate`)
+		return err
+	},
+	Unlock: func(ctx context.Context, conn *sql.Conn, runErr error) error {
+		end := `commit`
+		if runErr != nil {
+			end = `rollback`
+		}
+		_, err := conn.ExecContext(ctx, end)
+		return err
+	},
+}
+
+// ForDriver returns the dialect for a database/sql driver name.
+func ForDriver(driver string) (Dialect, error) {
+	switch driver {
+	case "mysql":
+		return MySQL, nil
+	case "postgres":
+		return Postgres, nil
+	case "sqlite":
+		return SQLite, nil
+	}
+	return Dialect{}, fmt.Errorf("migrate: no dialect for driver %q", driver)
+}
+
+// rebind rewrites "?" placeholders into the dialect's form.
+func (d Dialect) rebind(query string) string {
+	if d.Rebind == nil {
+		return query
+	}
+	return d.Rebind(query)
+}
@@ -0,0 +1,358 @@
+// Package migrate applies the versioned SQL migrations embedded in the binary
+// and records them in a schema_migrations table.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed migrations
+var embedded embed.FS
+
+// ErrDirty is returned when a previous run failed part way through a migration.
+var ErrDirty = errors.New("migrate: database is dirty, fix the schema by hand and clear the dirty flag")
+
+// Migration is one numbered schema change with its up and down scripts.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status describes a known migration and whether it has been applied.
+type Status struct {
+	Version   int
+	Name      string
+	Applied   bool
+	Dirty     bool
+	AppliedAt time.Time
+}
+
+// Migrator runs migrations for one dialect against a database.
+type Migrator struct {
+	DB         *sql.DB
+	Dialect    Dialect
+	Migrations []Migration
+}
+
+var fileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// New returns a Migrator loaded with the embedded migrations for dialect.
+func New(db *sql.DB, dialect Dialect) (*Migrator, error) {
+	sub, err := fs.Sub(embedded, path.Join("migrations", dialect.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	migrations, err := Load(sub)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{DB: db, Dialect: dialect, Migrations: migrations}, nil
+}
+
+// Load reads NNNN_name.up.sql / NNNN_name.down.sql pairs from fsys, ordered by version.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		m := fileName.FindStringSubmatch(e.Name())
+		if m == nil {
+			return nil, fmt.Errorf("migrate: unexpected file %q", e.Name())
+		}
+		version, _ := strconv.Atoi(m[1])
+		if version <= 0 {
+			return nil, fmt.Errorf("migrate: %q: version must be positive", e.Name())
+		}
+
+		body, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mig
+		} else if mig.Name != m[2] {
+			return nil, fmt.Errorf("migrate: version %d used by %q and %q", version, mig.Name, m[2])
+		}
+
+		if m[3] == "up" {
+			mig.Up = string(body)
+		} else {
+			mig.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if strings.TrimSpace(mig.Up) == "" || strings.TrimSpace(mig.Down) == "" {
+			return nil, fmt.Errorf("migrate: version %d (%s) needs both up and down scripts", mig.Version, mig.Name)
+		}
+		migrations = append(migrations, *mig)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// Up applies every pending migration and returns how many ran.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	latest := 0
+	if len(m.Migrations) > 0 {
+		latest = m.Migrations[len(m.Migrations)-1].Version
+	}
+	return m.To(ctx, latest)
+}
+
+// Down reverts the most recent steps migrations and returns how many ran.
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	if steps <= 0 {
+		return 0, nil
+	}
+
+	var n int
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for i := len(m.Migrations) - 1; i >= 0 && n < steps; i-- {
+			mig := m.Migrations[i]
+			if _, ok := applied[mig.Version]; !ok {
+				continue
+			}
+			if err := m.runDown(ctx, conn, mig); err != nil {
+				return err
+			}
+			n++
+		}
+		return nil
+	})
+	return n, err
+}
+
+// To migrates up or down until version is the newest applied migration.
+// Version 0 reverts everything.
+func (m *Migrator) To(ctx context.Context, version int) (int, error) {
+	if version != 0 && m.find(version) == nil {
+		return 0, fmt.Errorf("migrate: unknown version %d", version)
+	}
+
+	var n int
+	err := m.locked(ctx, func(conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.Migrations) - 1; i >= 0; i-- {
+			mig := m.Migrations[i]
+			if _, ok := applied[mig.Version]; ok && mig.Version > version {
+				if err := m.runDown(ctx, conn, mig); err != nil {
+					return err
+				}
+				n++
+			}
+		}
+
+		for _, mig := range m.Migrations {
+			if _, ok := applied[mig.Version]; !ok && mig.Version <= version {
+				if err := m.runUp(ctx, conn, mig); err != nil {
+					return err
+				}
+				n++
+			}
+		}
+		return nil
+	})
+	return n, err
+}
+
+// Status lists every known migration and any applied version missing from the binary.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	conn, err := m.DB.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, m.Dialect.CreateTable); err != nil {
+		return nil, err
+	}
+
+	rows, err := m.rows(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []Status
+	for _, mig := range m.Migrations {
+		s := Status{Version: mig.Version, Name: mig.Name}
+		if row, ok := rows[mig.Version]; ok {
+			s.Applied, s.Dirty, s.AppliedAt = true, row.Dirty, row.AppliedAt
+			delete(rows, mig.Version)
+		}
+		out = append(out, s)
+	}
+	for _, row := range rows {
+		out = append(out, row)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Version < out[j].Version })
+
+	return out, nil
+}
+
+// locked runs fn on a single connection holding the dialect's migration lock.
+func (m *Migrator) locked(ctx context.Context, fn func(*sql.Conn) error) error {
+	conn, err := m.DB.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := m.Dialect.Lock(ctx, conn); err != nil {
+		return err
+	}
+
+	_, err = conn.ExecContext(ctx, m.Dialect.CreateTable)
+	if err == nil {
+		err = fn(conn)
+	}
+
+	// unlocking ends SQLite's transaction, so its error counts when the run succeeded
+	if unlockErr := m.Dialect.Unlock(context.Background(), conn, err); err == nil {
+		err = unlockErr
+	}
+	return err
+}
+
+// applied returns the applied versions, refusing to continue if any are dirty.
+func (m *Migrator) applied(ctx context.Context, conn *sql.Conn) (map[int]Status, error) {
+	rows, err := m.rows(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+	for v, row := range rows {
+		if row.Dirty {
+			return nil, fmt.Errorf("%w (version %d)", ErrDirty, v)
+		}
+	}
+	return rows, nil
+}
+
+func (m *Migrator) rows(ctx context.Context, conn *sql.Conn) (map[int]Status, error) {
+	rows, err := conn.QueryContext(ctx, `select version, name, dirty, applied_at from schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make(map[int]Status)
+	for rows.Next() {
+		var s Status
+		if err := rows.Scan(&s.Version, &s.Name, &s.Dirty, &s.AppliedAt); err != nil {
+			return nil, err
+		}
+		s.Applied = true
+		out[s.Version] = s
+	}
+	return out, rows.Err()
+}
+
+// runUp marks the version dirty, runs its statements, then clears the flag.
+// DDL commits implicitly in MySQL, so the dirty flag is what records a half-applied step.
+func (m *Migrator) runUp(ctx context.Context, conn *sql.Conn, mig Migration) error {
+	_, err := conn.ExecContext(ctx, m.Dialect.rebind(`insert into schema_migrations (version, name, dirty, applied_at) values (?, ?, ?, ?)`),
+		mig.Version, mig.Name, true, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+
+	if err := m.exec(ctx, conn, mig.Up); err != nil {
+		return fmt.Errorf("migrate: %d_%s up: %w", mig.Version, mig.Name, err)
+	}
+
+	_, err = conn.ExecContext(ctx, m.Dialect.rebind(`update schema_migrations set dirty = ? where version = ?`), false, mig.Version)
+	return err
+}
+
+// runDown marks the version dirty, runs its down script, then forgets the version.
+func (m *Migrator) runDown(ctx context.Context, conn *sql.Conn, mig Migration) error {
+	_, err := conn.ExecContext(ctx, m.Dialect.rebind(`update schema_migrations set dirty = ? where version = ?`), true, mig.Version)
+	if err != nil {
+		return err
+	}
+
+	if err := m.exec(ctx, conn, mig.Down); err != nil {
+		return fmt.Errorf("migrate: %d_%s down: %w", mig.Version, mig.Name, err)
+	}
+
+	_, err = conn.ExecContext(ctx, m.Dialect.rebind(`delete from schema_migrations where version = ?`), mig.Version)
+	return err
+}
+
+func (m *Migrator) exec(ctx context.Context, conn *sql.Conn, script string) error {
+	for _, stmt := range Split(script) {
+		if _, err := conn.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) find(version int) *Migration {
+	for i := range m.Migrations {
+		if m.Migrations[i].Version == version {
+			return &m.Migrations[i]
+		}
+	}
+	return nil
+}
+
+// Split breaks a script into statements. Statements end with a ";" at the end
+// of a line and full-line "--" comments are dropped, so data containing
+// semicolons mid-line is left intact.
+func Split(script string) []string {
+	var (
+		stmts []string
+		buf   strings.Builder
+	)
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if (trimmed == "" && buf.Len() == 0) || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		buf.WriteString(line)
+		buf.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			stmts = append(stmts, strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(buf.String()), ";")))
+			buf.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(buf.String()); rest != "" {
+		stmts = append(stmts, rest)
+	}
+	return stmts
+}
@@ -0,0 +1,147 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	_ "modernc.org/sqlite"
+)
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0002_add_column.up.sql":   {Data: []byte("alter table t add column c int;")},
+		"0002_add_column.down.sql": {Data: []byte("alter table t drop column c;")},
+		"0001_create.up.sql":       {Data: []byte("create table t (id int);")},
+		"0001_create.down.sql":     {Data: []byte("drop table t;")},
+	}
+
+	migrations, err := Load(fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrations) != 2 {
+		t.Fatalf("expected 2 migrations, got %d", len(migrations))
+	}
+	if migrations[0].Version != 1 || migrations[1].Version != 2 {
+		t.Errorf("migrations not ordered by version: %d, %d", migrations[0].Version, migrations[1].Version)
+	}
+	if migrations[1].Name != "add_column" {
+		t.Errorf("expected name add_column, got %q", migrations[1].Name)
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{"missing down", fstest.MapFS{
+			"0001_create.up.sql": {Data: []byte("create table t (id int);")},
+		}},
+		{"bad file name", fstest.MapFS{
+			"create.sql": {Data: []byte("create table t (id int);")},
+		}},
+		{"duplicate version", fstest.MapFS{
+			"0001_a.up.sql":   {Data: []byte("select 1;")},
+			"0001_a.down.sql": {Data: []byte("select 1;")},
+			"0001_b.up.sql":   {Data: []byte("select 1;")},
+			"0001_b.down.sql": {Data: []byte("select 1;")},
+		}},
+		{"zero version", fstest.MapFS{
+			"0000_a.up.sql":   {Data: []byte("select 1;")},
+			"0000_a.down.sql": {Data: []byte("select 1;")},
+		}},
+	}
+
+	for _, tt := range tests {
+		if _, err := Load(tt.fsys); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	script := `-- a comment
+create table t (
+  id int
+);
+
+insert into t values
+(1, 'semi; colon'),
+(2, 'two');
+`
+	stmts := Split(script)
+	if len(stmts) != 2 {
+		t.Fatalf("expected 2 statements, got %d: %q", len(stmts), stmts)
+	}
+	if stmts[1] != "insert into t values\n(1, 'semi; colon'),\n(2, 'two')" {
+		t.Errorf("unexpected statement %q", stmts[1])
+	}
+}
+
+func TestEmbedded(t *testing.T) {
+	for _, dialect := range []Dialect{MySQL, Postgres, SQLite} {
+		m, err := New(nil, dialect)
+		if err != nil {
+			t.Fatalf("%s: %v", dialect.Name, err)
+		}
+		if len(m.Migrations) == 0 {
+			t.Fatalf("%s: expected embedded migrations", dialect.Name)
+		}
+		for i, mig := range m.Migrations {
+			if mig.Version != i+1 {
+				t.Errorf("%s: expected contiguous versions, got %d at position %d", dialect.Name, mig.Version, i)
+			}
+		}
+	}
+}
+
+func TestRebind(t *testing.T) {
+	got := Postgres.rebind(`update schema_migrations set dirty = ? where version = ?`)
+	if got != `update schema_migrations set dirty = $1 where version = $2` {
+		t.Errorf("unexpected query %q", got)
+	}
+}
+
+func TestSQLite_FailedRunRollsBack(t *testing.T) {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "migrate.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	ctx := context.Background()
+
+	m := &Migrator{DB: db, Dialect: SQLite, Migrations: []Migration{
+		{Version: 1, Name: "create_a", Up: "create table a (id integer);", Down: "drop table a;"},
+		{Version: 2, Name: "create_b", Up: "create table b (id integer);\ninsert into missing values (1);", Down: "drop table b;"},
+	}}
+
+	if _, err := m.Up(ctx); err == nil {
+		t.Fatal("expected the second migration to fail")
+	}
+	var tables int
+	if err := db.QueryRow(`select count(*) from sqlite_master where type = 'table' and name in ('a', 'b')`).Scan(&tables); err != nil {
+		t.Fatal(err)
+	}
+	if tables != 0 {
+		t.Errorf("expected the failed run to be rolled back, found %d of its tables", tables)
+	}
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range statuses {
+		if s.Applied || s.Dirty {
+			t.Errorf("version %d: expected nothing recorded, got applied=%v dirty=%v", s.Version, s.Applied, s.Dirty)
+		}
+	}
+
+	// once the script is fixed the same database migrates cleanly
+	m.Migrations[1].Up = "create table b (id integer);"
+	if n, err := m.Up(ctx); err != nil || n != 2 {
+		t.Fatalf("expected 2 migrations after the fix, got %d, %v", n, err)
+	}
+}
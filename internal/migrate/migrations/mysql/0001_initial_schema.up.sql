@@ -0,0 +1,76 @@
+-- Initial schema, matching sql/breeders_mysql.sql as first shipped.
+-- IF NOT EXISTS lets databases created from that dump adopt migrations.
+
+CREATE TABLE IF NOT EXISTS `breeders` (
+  `id` int(11) unsigned NOT NULL AUTO_INCREMENT,
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `cat_breeds` (
+  `id` int(11) unsigned NOT NULL AUTO_INCREMENT,
+  `breed` varchar(255) DEFAULT NULL,
+  `weight_low_lbs` int(11) DEFAULT NULL,
+  `weight_high_lbs` int(11) DEFAULT NULL,
+  `lifespan` int(11) DEFAULT NULL,
+  `details` text DEFAULT NULL,
+  `alternate_names` varchar(255) DEFAULT NULL,
+  `geographic_origin` varchar(255) DEFAULT NULL,
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `dog_breeds` (
+  `id` int(10) unsigned NOT NULL AUTO_INCREMENT,
+  `breed` varchar(255) DEFAULT NULL,
+  `weight_low_lbs` int(11) DEFAULT NULL,
+  `weight_high_lbs` int(11) DEFAULT NULL,
+  `lifespan` int(11) DEFAULT NULL,
+  `details` text DEFAULT NULL,
+  `alternate_names` varchar(255) DEFAULT NULL,
+  `geographic_origin` varchar(255) DEFAULT NULL,
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `cats` (
+  `id` int(11) unsigned NOT NULL AUTO_INCREMENT,
+  `cat_name` varchar(255) NOT NULL,
+  `breed_id` int(11) unsigned DEFAULT NULL,
+  `breeder_id` int(11) unsigned DEFAULT NULL,
+  `color` varchar(255) NOT NULL,
+  `date_of_birth` date NOT NULL,
+  `spayed_neutered` int(11) NOT NULL DEFAULT 0,
+  `description` text NOT NULL,
+  `weight` int(11) NOT NULL,
+  PRIMARY KEY (`id`),
+  KEY `breed_id` (`breed_id`),
+  KEY `breeder_id` (`breeder_id`),
+  CONSTRAINT `cats_ibfk_1` FOREIGN KEY (`breed_id`) REFERENCES `cat_breeds` (`id`) ON DELETE SET NULL ON UPDATE CASCADE,
+  CONSTRAINT `cats_ibfk_2` FOREIGN KEY (`breeder_id`) REFERENCES `breeders` (`id`) ON DELETE SET NULL ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `dogs` (
+  `id` int(11) unsigned NOT NULL AUTO_INCREMENT,
+  `dog_name` varchar(255) NOT NULL,
+  `breed_id` int(11) unsigned DEFAULT NULL,
+  `breeder_id` int(11) unsigned DEFAULT NULL,
+  `color` varchar(255) NOT NULL,
+  `date_of_birth` date NOT NULL,
+  `spayed_neutered` int(11) NOT NULL DEFAULT 0,
+  `description` text NOT NULL,
+  `weight` int(11) NOT NULL,
+  PRIMARY KEY (`id`),
+  KEY `breed_id` (`breed_id`),
+  KEY `breeder_id` (`breeder_id`),
+  CONSTRAINT `dogs_ibfk_1` FOREIGN KEY (`breed_id`) REFERENCES `dog_breeds` (`id`) ON DELETE SET NULL ON UPDATE CASCADE,
+  CONSTRAINT `dogs_ibfk_2` FOREIGN KEY (`breeder_id`) REFERENCES `breeders` (`id`) ON DELETE SET NULL ON UPDATE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS `users` (
+  `id` int(11) unsigned NOT NULL AUTO_INCREMENT,
+  `first_name` varchar(255) NOT NULL,
+  `last_name` varchar(255) NOT NULL,
+  `email` varchar(255) NOT NULL,
+  `password` varchar(60) NOT NULL,
+  `user_active` int(11) NOT NULL DEFAULT 0,
+  `access_level` int(11) NOT NULL DEFAULT 10,
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
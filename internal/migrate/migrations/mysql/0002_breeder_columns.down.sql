@@ -0,0 +1,10 @@
+ALTER TABLE `breeders`
+  DROP COLUMN IF EXISTS `breeder_name`,
+  DROP COLUMN IF EXISTS `address`,
+  DROP COLUMN IF EXISTS `city`,
+  DROP COLUMN IF EXISTS `prov_state`,
+  DROP COLUMN IF EXISTS `country`,
+  DROP COLUMN IF EXISTS `zip`,
+  DROP COLUMN IF EXISTS `phone`,
+  DROP COLUMN IF EXISTS `email`,
+  DROP COLUMN IF EXISTS `active`;
@@ -0,0 +1,13 @@
+-- The first breeders table only had an id column, so /api/breeders failed.
+-- IF NOT EXISTS makes this a no-op on databases created from the newer dump.
+
+ALTER TABLE `breeders`
+  ADD COLUMN IF NOT EXISTS `breeder_name` varchar(255) NOT NULL DEFAULT '' AFTER `id`,
+  ADD COLUMN IF NOT EXISTS `address` varchar(255) NOT NULL DEFAULT '' AFTER `breeder_name`,
+  ADD COLUMN IF NOT EXISTS `city` varchar(255) NOT NULL DEFAULT '' AFTER `address`,
+  ADD COLUMN IF NOT EXISTS `prov_state` varchar(255) NOT NULL DEFAULT '' AFTER `city`,
+  ADD COLUMN IF NOT EXISTS `country` varchar(255) NOT NULL DEFAULT '' AFTER `prov_state`,
+  ADD COLUMN IF NOT EXISTS `zip` varchar(20) NOT NULL DEFAULT '' AFTER `country`,
+  ADD COLUMN IF NOT EXISTS `phone` varchar(50) NOT NULL DEFAULT '' AFTER `zip`,
+  ADD COLUMN IF NOT EXISTS `email` varchar(255) NOT NULL DEFAULT '' AFTER `phone`,
+  ADD COLUMN IF NOT EXISTS `active` int(11) NOT NULL DEFAULT 1 AFTER `email`;
@@ -0,0 +1,3 @@
+DELETE FROM `users` WHERE `id` = 1;
+DELETE FROM `dog_breeds`;
+DELETE FROM `cat_breeds`;
@@ -0,0 +1,2 @@
+DROP INDEX `users_email` ON `users`;
+DROP TABLE IF EXISTS `sessions`;
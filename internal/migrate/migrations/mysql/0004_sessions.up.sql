@@ -0,0 +1,16 @@
+-- Server-side login sessions. The cookie holds a random token; only its
+-- SHA-256 hash is stored, so a leaked table cannot be replayed.
+
+CREATE TABLE IF NOT EXISTS `sessions` (
+  `token_hash` char(64) NOT NULL,
+  `user_id` int(11) unsigned NOT NULL,
+  `expiry` datetime NOT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`token_hash`),
+  KEY `sessions_expiry` (`expiry`),
+  CONSTRAINT `sessions_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- logins look users up by email, which must identify a single account.
+-- IF NOT EXISTS makes this a no-op on databases created from the newer dump.
+CREATE UNIQUE INDEX IF NOT EXISTS `users_email` ON `users` (`email`);
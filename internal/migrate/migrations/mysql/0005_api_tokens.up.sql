@@ -0,0 +1,17 @@
+-- Bearer tokens for machine clients. As with sessions only the SHA-256 of
+-- the token is stored; scopes is a comma-separated list.
+
+CREATE TABLE IF NOT EXISTS `api_tokens` (
+  `id` int(11) unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` int(11) unsigned NOT NULL,
+  `name` varchar(255) NOT NULL,
+  `token_hash` char(64) NOT NULL,
+  `scopes` varchar(255) NOT NULL,
+  `expires_at` datetime DEFAULT NULL,
+  `last_used_at` datetime DEFAULT NULL,
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `api_tokens_token_hash` (`token_hash`),
+  KEY `api_tokens_user_id` (`user_id`),
+  CONSTRAINT `api_tokens_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,8 @@
+-- Common names for popular breeds whose seed rows had no alternate names,
+-- so breed search finds "Alsatian" or "Lab". Rows edited since are left alone.
+
+UPDATE `dog_breeds` SET `alternate_names` = 'Alsatian' WHERE `id` = 477 AND `alternate_names` = '';
+UPDATE `dog_breeds` SET `alternate_names` = 'Lab' WHERE `id` = 503 AND `alternate_names` = '';
+UPDATE `dog_breeds` SET `alternate_names` = 'Sausage Dog, Wiener Dog' WHERE `id` = 458 AND `alternate_names` = '';
+UPDATE `dog_breeds` SET `alternate_names` = 'Corgi' WHERE `id` = 526 AND `alternate_names` = '';
+UPDATE `dog_breeds` SET `alternate_names` = 'Staffie' WHERE `id` = 561 AND `alternate_names` = '';
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS dogs;
+DROP TABLE IF EXISTS cats;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS dog_breeds;
+DROP TABLE IF EXISTS cat_breeds;
+DROP TABLE IF EXISTS breeders;
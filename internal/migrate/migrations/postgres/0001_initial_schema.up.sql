@@ -0,0 +1,78 @@
+-- Full schema; PostgreSQL support started after the breeders columns were added.
+
+CREATE TABLE IF NOT EXISTS breeders (
+  id serial PRIMARY KEY,
+  breeder_name varchar(255) NOT NULL,
+  address varchar(255) NOT NULL DEFAULT '',
+  city varchar(255) NOT NULL DEFAULT '',
+  prov_state varchar(255) NOT NULL DEFAULT '',
+  country varchar(255) NOT NULL DEFAULT '',
+  zip varchar(20) NOT NULL DEFAULT '',
+  phone varchar(50) NOT NULL DEFAULT '',
+  email varchar(255) NOT NULL DEFAULT '',
+  active integer NOT NULL DEFAULT 1
+);
+
+CREATE TABLE IF NOT EXISTS cat_breeds (
+  id serial PRIMARY KEY,
+  breed varchar(255),
+  weight_low_lbs integer,
+  weight_high_lbs integer,
+  lifespan integer,
+  details text,
+  alternate_names varchar(255),
+  geographic_origin varchar(255)
+);
+
+CREATE TABLE IF NOT EXISTS dog_breeds (
+  id serial PRIMARY KEY,
+  breed varchar(255),
+  weight_low_lbs integer,
+  weight_high_lbs integer,
+  lifespan integer,
+  details text,
+  alternate_names varchar(255),
+  geographic_origin varchar(255)
+);
+
+CREATE TABLE IF NOT EXISTS cats (
+  id serial PRIMARY KEY,
+  cat_name varchar(255) NOT NULL,
+  breed_id integer REFERENCES cat_breeds (id) ON DELETE SET NULL ON UPDATE CASCADE,
+  breeder_id integer REFERENCES breeders (id) ON DELETE SET NULL ON UPDATE CASCADE,
+  color varchar(255) NOT NULL,
+  date_of_birth date NOT NULL,
+  spayed_neutered integer NOT NULL DEFAULT 0,
+  description text NOT NULL,
+  weight integer NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS cats_breed_id ON cats (breed_id);
+
+CREATE INDEX IF NOT EXISTS cats_breeder_id ON cats (breeder_id);
+
+CREATE TABLE IF NOT EXISTS dogs (
+  id serial PRIMARY KEY,
+  dog_name varchar(255) NOT NULL,
+  breed_id integer REFERENCES dog_breeds (id) ON DELETE SET NULL ON UPDATE CASCADE,
+  breeder_id integer REFERENCES breeders (id) ON DELETE SET NULL ON UPDATE CASCADE,
+  color varchar(255) NOT NULL,
+  date_of_birth date NOT NULL,
+  spayed_neutered integer NOT NULL DEFAULT 0,
+  description text NOT NULL,
+  weight integer NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS dogs_breed_id ON dogs (breed_id);
+
+CREATE INDEX IF NOT EXISTS dogs_breeder_id ON dogs (breeder_id);
+
+CREATE TABLE IF NOT EXISTS users (
+  id serial PRIMARY KEY,
+  first_name varchar(255) NOT NULL,
+  last_name varchar(255) NOT NULL,
+  email varchar(255) NOT NULL,
+  password varchar(60) NOT NULL,
+  user_active integer NOT NULL DEFAULT 0,
+  access_level integer NOT NULL DEFAULT 10
+);
@@ -0,0 +1,14 @@
+-- Server-side login sessions. The cookie holds a random token; only its
+-- SHA-256 hash is stored, so a leaked table cannot be replayed.
+
+CREATE TABLE IF NOT EXISTS sessions (
+  token_hash char(64) PRIMARY KEY,
+  user_id integer NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  expiry datetime NOT NULL,
+  created_at datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS sessions_expiry ON sessions (expiry);
+
+-- logins look users up by email, which must identify a single account
+CREATE UNIQUE INDEX IF NOT EXISTS users_email ON users (email COLLATE NOCASE);
@@ -0,0 +1,15 @@
+-- Bearer tokens for machine clients. As with sessions only the SHA-256 of
+-- the token is stored; scopes is a comma-separated list.
+
+CREATE TABLE IF NOT EXISTS api_tokens (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  user_id integer NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+  name varchar(255) NOT NULL,
+  token_hash char(64) NOT NULL UNIQUE,
+  scopes varchar(255) NOT NULL,
+  expires_at datetime,
+  last_used_at datetime,
+  created_at datetime NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS api_tokens_user_id ON api_tokens (user_id);
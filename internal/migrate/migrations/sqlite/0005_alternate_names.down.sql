@@ -0,0 +1,5 @@
+UPDATE dog_breeds SET alternate_names = '' WHERE id = 477 AND alternate_names = 'Alsatian';
+UPDATE dog_breeds SET alternate_names = '' WHERE id = 503 AND alternate_names = 'Lab';
+UPDATE dog_breeds SET alternate_names = '' WHERE id = 458 AND alternate_names = 'Sausage Dog, Wiener Dog';
+UPDATE dog_breeds SET alternate_names = '' WHERE id = 526 AND alternate_names = 'Corgi';
+UPDATE dog_breeds SET alternate_names = '' WHERE id = 561 AND alternate_names = 'Staffie';
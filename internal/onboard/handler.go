@@ -0,0 +1,43 @@
+package onboard
+
+import (
+	"fmt"
+	"go-breeders/internal/apperr"
+	"net/http"
+
+	"github.com/tsawler/toolbox"
+)
+
+// maxJSONSize caps the request body, which may carry many animals (4 MB)
+const maxJSONSize = 4 << 20
+
+// Handler handles HTTP requests for breeder onboarding
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new onboarding handler
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// OnboardJSON registers the breeder, dogs and cats in the JSON body as one
+// unit and returns them with their IDs and the breeder's Location
+func (h *Handler) OnboardJSON(w http.ResponseWriter, r *http.Request) {
+	t := toolbox.Tools{MaxJSONSize: maxJSONSize}
+
+	var o Onboarding
+	if err := t.ReadJSON(w, r, &o); err != nil {
+		apperr.WriteJSON(w, apperr.New(apperr.BadRequest, err.Error()))
+		return
+	}
+
+	if err := h.service.Onboard(r.Context(), &o); err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	headers := make(http.Header)
+	headers.Set("Location", fmt.Sprintf("/api/breeders/%d", o.Breeder.ID))
+	_ = t.WriteJSON(w, http.StatusCreated, o, headers)
+}
@@ -0,0 +1,15 @@
+package onboard
+
+import (
+	"go-breeders/internal/breeder"
+	"go-breeders/internal/cat"
+	"go-breeders/internal/dog"
+)
+
+// Onboarding is a breeder registered together with their initial dogs and
+// cats. The animals' breeder_id is ignored; they belong to the new breeder.
+type Onboarding struct {
+	Breeder *breeder.Breeder `json:"breeder"`
+	Dogs    []*dog.Dog       `json:"dogs"`
+	Cats    []*cat.Cat       `json:"cats"`
+}
@@ -0,0 +1,95 @@
+package onboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-breeders/internal/apperr"
+	"go-breeders/internal/breeder"
+	"go-breeders/internal/cat"
+	"go-breeders/internal/dog"
+	"go-breeders/internal/uow"
+	"go-breeders/internal/validate"
+)
+
+// MaxAnimals caps the dogs plus cats in one onboarding
+const MaxAnimals = 100
+
+// Service registers breeders with their animals
+type Service struct {
+	work uow.UnitOfWork
+}
+
+// NewService creates a new onboarding service running on work
+func NewService(work uow.UnitOfWork) *Service {
+	return &Service{work: work}
+}
+
+// Onboard creates the breeder and then each dog and cat in one unit of work,
+// validating every record through its domain service, and fills in the new
+// IDs. If any record fails nothing is stored.
+func (s *Service) Onboard(ctx context.Context, o *Onboarding) error {
+	if len(o.Dogs)+len(o.Cats) > MaxAnimals {
+		return apperr.New(apperr.BadRequest, fmt.Sprintf("at most %d dogs and cats can be onboarded at once", MaxAnimals))
+	}
+	if err := validateRecords(o); err != nil {
+		return err
+	}
+
+	return s.work.Do(ctx, func(ctx context.Context, repos uow.Repositories) error {
+		breeders := breeder.NewService(repos.Breeders)
+		dogs := dog.NewService(repos.Dogs, repos.Breeders)
+		cats := cat.NewService(repos.Cats, repos.Breeders)
+
+		id, err := breeders.CreateBreeder(ctx, o.Breeder)
+		if err != nil {
+			return within(err, "breeder")
+		}
+		o.Breeder.ID = id
+
+		for i, d := range o.Dogs {
+			d.BreederID = id
+			if d.ID, err = dogs.CreateDog(ctx, d); err != nil {
+				return within(err, fmt.Sprintf("dogs[%d]", i))
+			}
+		}
+
+		for i, c := range o.Cats {
+			c.BreederID = id
+			if c.ID, err = cats.CreateCat(ctx, c); err != nil {
+				return within(err, fmt.Sprintf("cats[%d]", i))
+			}
+		}
+
+		return nil
+	})
+}
+
+// validateRecords checks that the breeder and every animal are present, as
+// a JSON null decodes to a nil record
+func validateRecords(o *Onboarding) error {
+	v := validate.New()
+	v.Check(o.Breeder != nil, "breeder", "is required")
+	for i, d := range o.Dogs {
+		v.Check(d != nil, fmt.Sprintf("dogs[%d]", i), "is required")
+	}
+	for i, c := range o.Cats {
+		v.Check(c != nil, fmt.Sprintf("cats[%d]", i), "is required")
+	}
+	return v.Err()
+}
+
+// within points a validation error at the record it came from, turning
+// field "weight" into "dogs[2].weight"
+func within(err error, record string) error {
+	var e *apperr.Error
+	if !errors.As(err, &e) || e.Kind != apperr.Validation {
+		return err
+	}
+
+	scoped := &apperr.Error{Kind: e.Kind, Message: record + ": " + e.Message, Err: e.Err}
+	for _, f := range e.Fields {
+		scoped.Fields = append(scoped.Fields, apperr.FieldError{Field: record + "." + f.Field, Message: f.Message})
+	}
+	return scoped
+}
@@ -0,0 +1,120 @@
+package onboard_test
+
+import (
+	"context"
+	"errors"
+	"go-breeders/internal/apperr"
+	"go-breeders/internal/breeder"
+	"go-breeders/internal/cat"
+	"go-breeders/internal/dog"
+	"go-breeders/internal/list"
+	"go-breeders/internal/onboard"
+	"go-breeders/internal/uow"
+	"testing"
+	"time"
+)
+
+// failingCats is a cat repository whose inserts fail, as a lost connection would
+type failingCats struct {
+	cat.Repository
+}
+
+func (failingCats) InsertCat(context.Context, *cat.Cat) (int, error) {
+	return 0, errors.New("connection lost")
+}
+
+// newService returns an onboarding service over the mock fixtures and the
+// breeder and dog repositories it writes to. With failCats set, cat inserts fail.
+func newService(failCats bool) (*onboard.Service, breeder.Repository, dog.Repository) {
+	breeders := breeder.NewMemoryRepository(breeder.MockFixtures())
+	dogs := dog.NewMemoryRepository(breeders, dog.MockFixtures())
+	var cats cat.Repository = cat.NewMemoryRepository(breeders, cat.MockFixtures())
+	if failCats {
+		cats = failingCats{cats}
+	}
+	return onboard.NewService(uow.NewMemory(uow.Repositories{Breeders: breeders, Dogs: dogs, Cats: cats})), breeders, dogs
+}
+
+func newDog() *dog.Dog {
+	return &dog.Dog{DogName: "Rex", BreedID: 2, Weight: 70, DateOfBirth: time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func newCat() *cat.Cat {
+	return &cat.Cat{CatName: "Tom", BreedID: 1, Weight: 10, DateOfBirth: time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func TestService_Onboard(t *testing.T) {
+	service, _, _ := newService(false)
+	o := &onboard.Onboarding{
+		Breeder: &breeder.Breeder{BreederName: "Pack Leaders", Active: 1},
+		Dogs:    []*dog.Dog{newDog()},
+		Cats:    []*cat.Cat{newCat()},
+	}
+
+	if err := service.Onboard(context.Background(), o); err != nil {
+		t.Fatal(err)
+	}
+	if o.Breeder.ID == 0 || o.Dogs[0].ID == 0 || o.Cats[0].ID == 0 {
+		t.Fatalf("expected every record to get an ID, got %+v", o)
+	}
+	if o.Dogs[0].BreederID != o.Breeder.ID || o.Cats[0].BreederID != o.Breeder.ID {
+		t.Errorf("expected the animals to belong to breeder %d", o.Breeder.ID)
+	}
+}
+
+func TestService_OnboardMissingRecords(t *testing.T) {
+	tests := []struct {
+		name  string
+		o     onboard.Onboarding
+		field string
+	}{
+		{"no breeder", onboard.Onboarding{Dogs: []*dog.Dog{newDog()}}, "breeder"},
+		{"null dog", onboard.Onboarding{Breeder: &breeder.Breeder{BreederName: "A"}, Dogs: []*dog.Dog{newDog(), nil}}, "dogs[1]"},
+		{"null cat", onboard.Onboarding{Breeder: &breeder.Breeder{BreederName: "A"}, Cats: []*cat.Cat{nil}}, "cats[0]"},
+	}
+
+	for _, tt := range tests {
+		service, _, _ := newService(false)
+		err := service.Onboard(context.Background(), &tt.o)
+		var e *apperr.Error
+		if !errors.As(err, &e) || e.Kind != apperr.Validation || len(e.Fields) != 1 || e.Fields[0].Field != tt.field {
+			t.Errorf("%s: expected a validation error on %s, got %v", tt.name, tt.field, err)
+		}
+	}
+}
+
+func TestService_OnboardTooManyAnimals(t *testing.T) {
+	service, breeders, _ := newService(false)
+	o := &onboard.Onboarding{Breeder: &breeder.Breeder{BreederName: "Puppy Mill", Active: 1}}
+	for range onboard.MaxAnimals {
+		o.Dogs = append(o.Dogs, newDog())
+	}
+	o.Cats = []*cat.Cat{newCat()}
+
+	if err := service.Onboard(context.Background(), o); !apperr.Is(err, apperr.BadRequest) {
+		t.Fatalf("expected more than %d animals to be refused, got %v", onboard.MaxAnimals, err)
+	}
+	if _, total, _ := breeders.AllBreeders(context.Background(), list.Options{}); total != 2 {
+		t.Errorf("expected no breeder to be stored, got %d breeders", total)
+	}
+}
+
+func TestService_OnboardRollsBack(t *testing.T) {
+	ctx := context.Background()
+	service, breeders, dogs := newService(true)
+	o := &onboard.Onboarding{
+		Breeder: &breeder.Breeder{BreederName: "Pack Leaders", Active: 1},
+		Dogs:    []*dog.Dog{newDog()},
+		Cats:    []*cat.Cat{newCat()},
+	}
+
+	if err := service.Onboard(ctx, o); err == nil {
+		t.Fatal("expected the failed cat insert to fail the onboarding")
+	}
+	if _, total, _ := breeders.AllBreeders(ctx, list.Options{}); total != 2 {
+		t.Errorf("expected the breeder insert to be undone, got %d breeders", total)
+	}
+	if _, total, _ := dogs.AllDogs(ctx, list.Options{}); total != 2 {
+		t.Errorf("expected the dog insert to be undone, got %d dogs", total)
+	}
+}
@@ -0,0 +1,51 @@
+package search
+
+import (
+	"go-breeders/internal/apperr"
+	"net/http"
+	"strconv"
+
+	"github.com/tsawler/toolbox"
+)
+
+// Handler handles HTTP requests for breed search
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new search handler
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// response is the JSON body returned by SearchBreedsJSON
+type response struct {
+	Query string   `json:"query"`
+	Data  []Result `json:"data"`
+}
+
+// SearchBreedsJSON searches dog and cat breeds for ?q= and returns ranked
+// matches as JSON. ?limit= caps the number of results.
+func (h *Handler) SearchBreedsJSON(w http.ResponseWriter, r *http.Request) {
+	var t toolbox.Tools
+
+	q := r.URL.Query().Get("q")
+
+	limit := DefaultLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			apperr.WriteJSON(w, apperr.New(apperr.BadRequest, "invalid limit"))
+			return
+		}
+		limit = n
+	}
+
+	results, err := h.service.Search(r.Context(), q, limit)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	_ = t.WriteJSON(w, http.StatusOK, response{Query: q, Data: results})
+}
@@ -0,0 +1,102 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Weights applied to a match depending on which field it was found in
+const (
+	nameWeight      = 1.0
+	alternateWeight = 0.9
+	detailsWeight   = 0.5
+)
+
+// tokenize lowercases s and splits it into letter/digit runs
+func tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// maxTypos is how many edits a query token of length n may be away from a match
+func maxTypos(n int) int {
+	switch {
+	case n >= 6:
+		return 2
+	case n >= 4:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// tokenScore scores how well query token q matches text token t, in [0, 1]
+func tokenScore(q, t string, fuzzy bool) float64 {
+	if q == t {
+		return 1
+	}
+	if len(q) >= 2 && strings.HasPrefix(t, q) {
+		// "lab" should find "labrador", but rank below an exact word
+		return 0.8 + 0.1*float64(len(q))/float64(len(t))
+	}
+	if !fuzzy {
+		return 0
+	}
+
+	qr, tr := []rune(q), []rune(t)
+	d := distance(qr, tr)
+	if d > maxTypos(len(qr)) {
+		return 0
+	}
+	return 0.85 * (1 - float64(d)/float64(max(len(qr), len(tr))))
+}
+
+// phraseScore scores query tokens against the tokens of one field. Every
+// query token must match something; the result is the average best score.
+func phraseScore(query, text []string, fuzzy bool) float64 {
+	if len(text) == 0 {
+		return 0
+	}
+
+	var total float64
+	for _, q := range query {
+		var best float64
+		for _, t := range text {
+			best = max(best, tokenScore(q, t, fuzzy))
+		}
+		if best == 0 {
+			return 0
+		}
+		total += best
+	}
+	return total / float64(len(query))
+}
+
+// distance returns the optimal string alignment distance between a and b:
+// insertions, deletions, substitutions and adjacent transpositions each cost 1
+func distance(a, b []rune) int {
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+
+	return prev[len(b)]
+}
@@ -0,0 +1,71 @@
+package search
+
+import "testing"
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "lab", 3},
+		{"lab", "lab", 0},
+		{"sheperd", "shepherd", 1},  // insertion
+		{"beagel", "beagle", 1},     // transposition
+		{"alsation", "alsatian", 1}, // substitution
+		{"poodle", "pudel", 3},      // mixed edits
+		{"chihuahua", "chiuaua", 2}, // two deletions
+		{"café", "cafe", 1},         // runes, not bytes
+	}
+
+	for _, tt := range tests {
+		if got := distance([]rune(tt.a), []rune(tt.b)); got != tt.want {
+			t.Errorf("distance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := distance([]rune(tt.b), []rune(tt.a)); got != tt.want {
+			t.Errorf("distance(%q, %q) = %d, want %d", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestMaxTypos(t *testing.T) {
+	tests := []struct{ n, want int }{
+		{0, 0}, {3, 0}, {4, 1}, {5, 1}, {6, 2}, {12, 2},
+	}
+
+	for _, tt := range tests {
+		if got := maxTypos(tt.n); got != tt.want {
+			t.Errorf("maxTypos(%d) = %d, want %d", tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestTokenScore(t *testing.T) {
+	tests := []struct {
+		name  string
+		q, t  string
+		fuzzy bool
+		lo    float64
+		hi    float64
+	}{
+		{"exact", "lab", "lab", false, 1, 1},
+		{"prefix", "lab", "labrador", false, 0.8, 0.9},
+		{"one letter is not a prefix", "l", "labrador", true, 0, 0},
+		{"typo", "alsation", "alsatian", true, 0.7, 0.8},
+		{"typo without fuzzy", "alsation", "alsatian", false, 0, 0},
+		{"short words need to be exact", "cat", "bat", true, 0, 0},
+		{"too many typos", "poodle", "pudel", true, 0, 0},
+	}
+
+	for _, tt := range tests {
+		if got := tokenScore(tt.q, tt.t, tt.fuzzy); got < tt.lo || got > tt.hi {
+			t.Errorf("%s: tokenScore(%q, %q) = %.3f, want between %.3f and %.3f", tt.name, tt.q, tt.t, got, tt.lo, tt.hi)
+		}
+	}
+
+	// an exact word beats a prefix, which beats a typo
+	exact, prefix, typo := tokenScore("lab", "lab", true), tokenScore("lab", "labrador", true), tokenScore("labs", "lab", true)
+	if !(exact > prefix && prefix > typo) {
+		t.Errorf("expected exact > prefix > typo, got %.3f, %.3f, %.3f", exact, prefix, typo)
+	}
+}
@@ -0,0 +1,145 @@
+package search
+
+import (
+	"context"
+	"go-breeders/internal/apperr"
+	"go-breeders/internal/cat"
+	"go-breeders/internal/dog"
+	"go-breeders/internal/list"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultLimit is the number of results returned when ?limit= is not given
+	DefaultLimit = 20
+	// MaxLimit caps ?limit=
+	MaxLimit = 100
+	// minScore drops weak matches so typo tolerance does not flood results
+	minScore = 0.3
+)
+
+// DogBreeds is the part of dog.Repository search reads from
+type DogBreeds interface {
+	AllBreeds(ctx context.Context, opts list.Options) ([]*dog.Breed, int, error)
+}
+
+// CatBreeds is the part of cat.Repository search reads from
+type CatBreeds interface {
+	AllBreeds(ctx context.Context, opts list.Options) ([]*cat.Breed, int, error)
+}
+
+// Result is a single ranked breed match
+type Result struct {
+	Species          string  `json:"species"`
+	ID               int     `json:"id"`
+	Breed            string  `json:"breed"`
+	AlternateNames   string  `json:"alternate_names"`
+	GeographicOrigin string  `json:"geographic_origin"`
+	MatchedOn        string  `json:"matched_on"`
+	Score            float64 `json:"score"`
+}
+
+// candidate is a breed from either species, flattened for matching
+type candidate struct {
+	species, breed, alternateNames, details, origin string
+	id                                              int
+}
+
+// Service searches dog and cat breeds by name, alternate names and details
+type Service struct {
+	dogs DogBreeds
+	cats CatBreeds
+}
+
+// NewService creates a new breed search service
+func NewService(dogs DogBreeds, cats CatBreeds) *Service {
+	return &Service{dogs: dogs, cats: cats}
+}
+
+// Search returns up to limit breeds matching q, best match first.
+// Breed tables are small reference data, so matching runs in memory where
+// typo tolerance is possible, rather than in SQL.
+func (s *Service) Search(ctx context.Context, q string, limit int) ([]Result, error) {
+	query := tokenize(q)
+	if utf8.RuneCountInString(strings.TrimSpace(q)) < 2 || len(query) == 0 {
+		return nil, apperr.New(apperr.BadRequest, "search query must be at least 2 characters")
+	}
+
+	candidates, err := s.candidates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []Result{}
+	for _, c := range candidates {
+		score, matchedOn := scoreCandidate(query, c)
+		if score < minScore {
+			continue
+		}
+		results = append(results, Result{
+			Species:          c.species,
+			ID:               c.id,
+			Breed:            c.breed,
+			AlternateNames:   c.alternateNames,
+			GeographicOrigin: c.origin,
+			MatchedOn:        matchedOn,
+			Score:            float64(int(score*1000+0.5)) / 1000,
+		})
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Breed < results[j].Breed
+	})
+
+	if limit < 1 {
+		limit = DefaultLimit
+	}
+	if limit = min(limit, MaxLimit); len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// candidates loads every dog and cat breed
+func (s *Service) candidates(ctx context.Context) ([]candidate, error) {
+	dogBreeds, _, err := s.dogs.AllBreeds(ctx, list.All())
+	if err != nil {
+		return nil, err
+	}
+	catBreeds, _, err := s.cats.AllBreeds(ctx, list.All())
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]candidate, 0, len(dogBreeds)+len(catBreeds))
+	for _, b := range dogBreeds {
+		candidates = append(candidates, candidate{"dog", b.Breed, b.AlternateNames, b.Details, b.GeographicOrigin, b.ID})
+	}
+	for _, b := range catBreeds {
+		candidates = append(candidates, candidate{"cat", b.Breed, b.AlternateNames, b.Details, b.GeographicOrigin, b.ID})
+	}
+	return candidates, nil
+}
+
+// scoreCandidate returns the best weighted score for query across the
+// candidate's fields and the name of the field that produced it
+func scoreCandidate(query []string, c candidate) (float64, string) {
+	best, matchedOn := nameWeight*phraseScore(query, tokenize(c.breed), true), "name"
+
+	for _, alt := range strings.Split(c.alternateNames, ",") {
+		if score := alternateWeight * phraseScore(query, tokenize(alt), true); score > best {
+			best, matchedOn = score, "alternate_name"
+		}
+	}
+
+	if score := detailsWeight * phraseScore(query, tokenize(c.details), false); score > best {
+		best, matchedOn = score, "details"
+	}
+
+	return best, matchedOn
+}
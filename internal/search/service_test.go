@@ -0,0 +1,87 @@
+package search
+
+import (
+	"context"
+	"go-breeders/internal/cat"
+	"go-breeders/internal/database/databasetest"
+	"go-breeders/internal/dog"
+	"go-breeders/internal/list"
+	"testing"
+)
+
+type dogBreeds []*dog.Breed
+
+func (d dogBreeds) AllBreeds(context.Context, list.Options) ([]*dog.Breed, int, error) {
+	return d, len(d), nil
+}
+
+type catBreeds []*cat.Breed
+
+func (c catBreeds) AllBreeds(context.Context, list.Options) ([]*cat.Breed, int, error) {
+	return c, len(c), nil
+}
+
+func TestService_SearchOrdering(t *testing.T) {
+	service := NewService(
+		dogBreeds{
+			{ID: 1, Breed: "Labrador Retriever", AlternateNames: "Lab"},
+			{ID: 2, Breed: "Golden Retriever", Details: "A retriever bred in Scotland"},
+			{ID: 3, Breed: "Flat-Coated Retriever"},
+			{ID: 4, Breed: "Beagle", Details: "A small scent hound"},
+			{ID: 5, Breed: "Labradoodle"},
+		},
+		catBreeds{
+			{ID: 1, Breed: "Laperm"},
+		},
+	)
+
+	tests := []struct {
+		name string
+		q    string
+		want []string
+	}{
+		// equal scores fall back to alphabetical order
+		{"ties by name", "retriever", []string{"Flat-Coated Retriever", "Golden Retriever", "Labrador Retriever"}},
+		// an exact alternate name beats a prefix of a name
+		{"exact before prefix", "lab", []string{"Labrador Retriever", "Labradoodle"}},
+		// every word must match within the same field
+		{"all words in details", "scotland retriever", []string{"Golden Retriever"}},
+		{"cats too", "laperm", []string{"Laperm"}},
+		{"typo", "beagel", []string{"Beagle"}},
+		{"details", "scent", []string{"Beagle"}},
+	}
+
+	for _, tt := range tests {
+		results, err := service.Search(context.Background(), tt.q, 0)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		got := make([]string, len(results))
+		for i, r := range results {
+			got[i] = r.Breed
+		}
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestService_SearchSeededAlternateNames(t *testing.T) {
+	db := databasetest.SQLite(t)
+	service := NewService(dog.NewSQLiteRepository(db, 0), cat.NewSQLiteRepository(db, 0))
+
+	results, err := service.Search(context.Background(), "alsation", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) == 0 || results[0].Breed != "German Shepherd Dog" || results[0].MatchedOn != "alternate_name" {
+		t.Fatalf("expected the German Shepherd Dog by alternate name first, got %+v", results)
+	}
+}
@@ -0,0 +1,45 @@
+package signed
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSigner(t *testing.T) {
+	s := New([]byte("secret"))
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	token := s.Sign("reset", "42.a.b", time.Hour)
+
+	if value, err := s.Verify("reset", token); err != nil || value != "42.a.b" {
+		t.Errorf("Verify = %q, %v", value, err)
+	}
+
+	tests := []struct {
+		name     string
+		signer   *Signer
+		purpose  string
+		token    string
+		expected error
+	}{
+		{"other purpose", s, "activate", token, ErrInvalid},
+		{"other key", New([]byte("other")), "reset", token, ErrInvalid},
+		{"tampered", s, "reset", "x" + token[1:], ErrInvalid},
+		{"no signature", s, "reset", token[:len(token)-44], ErrInvalid},
+		{"empty", s, "reset", "", ErrInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.signer.Verify(tt.purpose, tt.token); !errors.Is(err, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+
+	now = now.Add(time.Hour)
+	if _, err := s.Verify("reset", token); !errors.Is(err, ErrExpired) {
+		t.Errorf("expected ErrExpired, got %v", err)
+	}
+}
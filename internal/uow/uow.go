@@ -0,0 +1,93 @@
+// Package uow runs work that spans the domain repositories as one unit, so
+// either all of its writes are kept or none are.
+package uow
+
+import (
+	"context"
+	"database/sql"
+	"go-breeders/internal/breeder"
+	"go-breeders/internal/cat"
+	"go-breeders/internal/database"
+	"go-breeders/internal/dog"
+	"go-breeders/internal/user"
+	"sync"
+)
+
+// Repositories holds one Repository per domain
+type Repositories struct {
+	Breeders breeder.Repository
+	Dogs     dog.Repository
+	Cats     cat.Repository
+	Users    user.Repository
+}
+
+// Func is the work done in a unit; repos are only valid until it returns
+type Func func(ctx context.Context, repos Repositories) error
+
+// UnitOfWork runs fn against repositories whose writes are kept only if fn
+// returns nil
+type UnitOfWork interface {
+	Do(ctx context.Context, fn Func) error
+}
+
+// Binder builds the repositories for one backend on top of a database handle
+type Binder func(db database.DBTX) Repositories
+
+// SQL is a UnitOfWork that runs each unit in a database transaction
+type SQL struct {
+	db   *sql.DB
+	bind Binder
+}
+
+// NewSQL creates a UnitOfWork on db; bind builds the repositories for each transaction
+func NewSQL(db *sql.DB, bind Binder) UnitOfWork {
+	return &SQL{db: db, bind: bind}
+}
+
+// Do runs fn in a transaction. Deadlocks and serialization failures run fn
+// again from the start, see database.InTx.
+func (u *SQL) Do(ctx context.Context, fn Func) error {
+	return database.InTx(ctx, u.db, func(tx *sql.Tx) error {
+		return fn(ctx, u.bind(tx))
+	})
+}
+
+// snapshotter is implemented by the in-memory repositories
+type snapshotter interface {
+	Snapshot() (restore func())
+}
+
+// Memory is a UnitOfWork for the in-memory repositories. Units run one at a
+// time, and a failed unit restores the repositories to how it found them.
+// Writes made outside a unit while it runs are not isolated from it, which
+// is fine for tests and local runs but not for production.
+type Memory struct {
+	mu    sync.Mutex
+	repos Repositories
+}
+
+// NewMemory creates a UnitOfWork over repos
+func NewMemory(repos Repositories) UnitOfWork {
+	return &Memory{repos: repos}
+}
+
+// Do runs fn on the repositories and undoes its writes if it fails
+func (u *Memory) Do(ctx context.Context, fn Func) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	var restores []func()
+	for _, repo := range []any{u.repos.Breeders, u.repos.Dogs, u.repos.Cats, u.repos.Users} {
+		if s, ok := repo.(snapshotter); ok {
+			restores = append(restores, s.Snapshot())
+		}
+	}
+
+	if err := fn(ctx, u.repos); err != nil {
+		for _, restore := range restores {
+			restore()
+		}
+		return err
+	}
+	return nil
+}
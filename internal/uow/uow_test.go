@@ -0,0 +1,96 @@
+package uow_test
+
+import (
+	"context"
+	"errors"
+	"go-breeders/internal/apperr"
+	"go-breeders/internal/breeder"
+	"go-breeders/internal/cat"
+	"go-breeders/internal/database"
+	"go-breeders/internal/database/databasetest"
+	"go-breeders/internal/dog"
+	"go-breeders/internal/list"
+	"go-breeders/internal/uow"
+	"testing"
+	"time"
+)
+
+func TestSQL(t *testing.T) {
+	db := databasetest.SQLite(t)
+	work := uow.NewSQL(db, func(db database.DBTX) uow.Repositories {
+		return uow.Repositories{
+			Breeders: breeder.NewSQLiteRepository(db, 0),
+			Dogs:     dog.NewSQLiteRepository(db, 0),
+			Cats:     cat.NewSQLiteRepository(db, 0),
+		}
+	})
+	breeders := breeder.NewSQLiteRepository(db, 0)
+
+	runUnitOfWorkTests(t, work, breeders)
+}
+
+func TestMemory(t *testing.T) {
+	breeders := breeder.NewMemoryRepository(breeder.MockFixtures())
+	work := uow.NewMemory(uow.Repositories{
+		Breeders: breeders,
+		Dogs:     dog.NewMemoryRepository(breeders, dog.MockFixtures()),
+		Cats:     cat.NewMemoryRepository(breeders, cat.MockFixtures()),
+	})
+
+	runUnitOfWorkTests(t, work, breeders)
+}
+
+// runUnitOfWorkTests checks work through breeders, which sits outside any unit
+func runUnitOfWorkTests(t *testing.T, work uow.UnitOfWork, breeders breeder.Repository) {
+	ctx := context.Background()
+
+	// addAll inserts a breeder, a dog and a cat, returning the breeder ID
+	addAll := func(ctx context.Context, repos uow.Repositories) (int, error) {
+		id, err := repos.Breeders.InsertBreeder(ctx, &breeder.Breeder{BreederName: "Unit Kennels", Active: 1})
+		if err != nil {
+			return 0, err
+		}
+		// dog breed IDs differ between the fixtures and the seeded databases
+		breeds, _, err := repos.Dogs.AllBreeds(ctx, list.Options{PerPage: 1})
+		if err != nil {
+			return 0, err
+		}
+		dob := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+		if _, err := repos.Dogs.InsertDog(ctx, &dog.Dog{DogName: "Rex", BreedID: breeds[0].ID, BreederID: id, DateOfBirth: dob, Weight: 20}); err != nil {
+			return 0, err
+		}
+		_, err = repos.Cats.InsertCat(ctx, &cat.Cat{CatName: "Tom", BreedID: 1, BreederID: id, DateOfBirth: dob, Weight: 10})
+		return id, err
+	}
+
+	t.Run("commits", func(t *testing.T) {
+		var id int
+		err := work.Do(ctx, func(ctx context.Context, repos uow.Repositories) (err error) {
+			id, err = addAll(ctx, repos)
+			return err
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := breeders.GetBreederByID(ctx, id); err != nil {
+			t.Errorf("expected breeder %d to be stored: %v", id, err)
+		}
+	})
+
+	t.Run("rolls back every domain", func(t *testing.T) {
+		var id int
+		boom := errors.New("boom")
+		err := work.Do(ctx, func(ctx context.Context, repos uow.Repositories) (err error) {
+			if id, err = addAll(ctx, repos); err != nil {
+				return err
+			}
+			return boom
+		})
+		if !errors.Is(err, boom) {
+			t.Fatalf("expected the callback's error, got %v", err)
+		}
+		if _, err := breeders.GetBreederByID(ctx, id); !apperr.Is(err, apperr.NotFound) {
+			t.Errorf("expected breeder %d to be rolled back, got %v", id, err)
+		}
+	})
+}
@@ -0,0 +1,273 @@
+package user
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"go-breeders/internal/apperr"
+	"go-breeders/internal/mailer"
+	"go-breeders/internal/signed"
+	"go-breeders/internal/validate"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// How long the links sent by email stay valid
+const (
+	ActivationTTL = 48 * time.Hour
+	ResetTTL      = time.Hour
+)
+
+// Purposes the email links are signed for, so one kind cannot stand in for another
+const (
+	activatePurpose = "activate"
+	resetPurpose    = "reset-password"
+)
+
+// errBadLink is returned for a tampered, expired or already used link
+var errBadLink = apperr.New(apperr.BadRequest, "this link is invalid or has expired")
+
+// Registration is what a visitor enters to sign up
+type Registration struct {
+	FirstName string
+	LastName  string
+	Email     string
+	Password  string
+}
+
+// Accounts runs sign-up, email activation and password reset. The links it
+// mails carry signed tokens, so nothing about them is stored.
+type Accounts struct {
+	repo    Repository
+	signer  *signed.Signer
+	mail    mailer.Sender
+	baseURL string
+}
+
+// NewAccounts creates the account flows. Links in emails start with baseURL,
+// e.g. "https://breeders.example.com".
+func NewAccounts(repo Repository, signer *signed.Signer, mail mailer.Sender, baseURL string) *Accounts {
+	return &Accounts{repo: repo, signer: signer, mail: mail, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// Register creates an inactive viewer account and emails its activation link.
+// An email that is already registered gets its activation link again, or a
+// note that the account exists if it is active. Either way Register succeeds
+// and send failures are only logged, so the response does not reveal who has
+// an account and a failed email never leaves a visitor locked out.
+func (a *Accounts) Register(ctx context.Context, reg Registration) error {
+	v := validate.New()
+	v.Required("first_name", reg.FirstName)
+	v.MaxLength("first_name", reg.FirstName, 255)
+	v.Required("last_name", reg.LastName)
+	v.MaxLength("last_name", reg.LastName, 255)
+	v.Required("email", reg.Email)
+	v.MaxLength("email", reg.Email, 255)
+	v.Email("email", reg.Email)
+	checkPassword(v, reg.Password)
+	if err := v.Err(); err != nil {
+		return err
+	}
+
+	hash, err := HashPassword(reg.Password)
+	if err != nil {
+		return err
+	}
+
+	u := &User{
+		FirstName:   reg.FirstName,
+		LastName:    reg.LastName,
+		Email:       reg.Email,
+		Password:    hash,
+		AccessLevel: int(Viewer),
+	}
+	id, err := a.repo.InsertUser(ctx, u)
+	switch {
+	case apperr.Is(err, apperr.Conflict):
+		err = a.sendRegistered(ctx, reg.Email)
+	case err != nil:
+		return err
+	default:
+		u.ID = id
+		err = a.sendActivation(ctx, u)
+	}
+	if err != nil {
+		log.Println("error sending registration email:", err)
+	}
+	return nil
+}
+
+// Activate marks the account in an activation link active. Following the
+// link again is harmless.
+func (a *Accounts) Activate(ctx context.Context, token string) (*User, error) {
+	value, err := a.signer.Verify(activatePurpose, token)
+	if err != nil {
+		return nil, errBadLink
+	}
+	id, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, errBadLink
+	}
+
+	u, err := a.repo.GetUserByID(ctx, id)
+	if apperr.Is(err, apperr.NotFound) {
+		return nil, errBadLink
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !u.Active() {
+		if err := a.repo.ActivateUser(ctx, u.ID); err != nil {
+			return nil, err
+		}
+		u.UserActive = 1
+	}
+	return u, nil
+}
+
+// RequestPasswordReset emails a reset link to the account with email. An
+// account that was never activated gets its activation link again instead.
+// Unknown emails are ignored and send failures only logged, so the response
+// does not reveal who has an account.
+func (a *Accounts) RequestPasswordReset(ctx context.Context, email string) error {
+	u, err := a.repo.GetUserByEmail(ctx, email)
+	if apperr.Is(err, apperr.NotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !u.Active() {
+		err = a.sendActivation(ctx, u)
+	} else {
+		token := a.signer.Sign(resetPurpose, strconv.Itoa(u.ID)+"."+fingerprint(u.Password), ResetTTL)
+		err = a.send(ctx, u, "Reset your Go Breeders password", fmt.Sprintf(
+			"Hi %s,\n\nSomeone asked to reset the password for your Go Breeders account. "+
+				"If it was you, choose a new password here:\n\n%s\n\n"+
+				"The link expires in %s. If you did not ask for this, you can ignore this email.\n",
+			u.FirstName, a.link("/reset-password", token), hours(ResetTTL)))
+	}
+	if err != nil {
+		log.Println("error sending password reset email:", err)
+	}
+	return nil
+}
+
+// CheckResetToken returns the user a password reset link is for, or an
+// error if the link is bad, expired or was already used
+func (a *Accounts) CheckResetToken(ctx context.Context, token string) (*User, error) {
+	value, err := a.signer.Verify(resetPurpose, token)
+	if err != nil {
+		return nil, errBadLink
+	}
+	idText, passwordPrint, ok := strings.Cut(value, ".")
+	id, err := strconv.Atoi(idText)
+	if !ok || err != nil {
+		return nil, errBadLink
+	}
+
+	u, err := a.repo.GetUserByID(ctx, id)
+	if apperr.Is(err, apperr.NotFound) {
+		return nil, errBadLink
+	}
+	if err != nil {
+		return nil, err
+	}
+	// the link names the password it replaces, so it stops working once used
+	if passwordPrint != fingerprint(u.Password) || !u.Active() {
+		return nil, errBadLink
+	}
+	return u, nil
+}
+
+// ResetPassword sets a new password through a reset link and ends every
+// session of the account
+func (a *Accounts) ResetPassword(ctx context.Context, token, password string) error {
+	u, err := a.CheckResetToken(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	v := validate.New()
+	checkPassword(v, password)
+	if err := v.Err(); err != nil {
+		return err
+	}
+
+	hash, err := HashPassword(password)
+	if err != nil {
+		return err
+	}
+	if err := a.repo.UpdatePassword(ctx, u.ID, hash); err != nil {
+		return err
+	}
+	return a.repo.DeleteUserSessions(ctx, u.ID)
+}
+
+// sendActivation emails u the link that activates their account
+func (a *Accounts) sendActivation(ctx context.Context, u *User) error {
+	token := a.signer.Sign(activatePurpose, strconv.Itoa(u.ID), ActivationTTL)
+	return a.send(ctx, u, "Activate your Go Breeders account", fmt.Sprintf(
+		"Hi %s,\n\nPlease confirm your email address to activate your Go Breeders account:\n\n%s\n\n"+
+			"The link expires in %s.\n",
+		u.FirstName, a.link("/activate", token), hours(ActivationTTL)))
+}
+
+// sendRegistered answers a sign-up for an email that already has an
+// account: an inactive account gets its activation link again, an active
+// one a reminder of how to log in
+func (a *Accounts) sendRegistered(ctx context.Context, email string) error {
+	u, err := a.repo.GetUserByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+	if !u.Active() {
+		return a.sendActivation(ctx, u)
+	}
+	return a.send(ctx, u, "You already have a Go Breeders account", fmt.Sprintf(
+		"Hi %s,\n\nSomeone tried to create a Go Breeders account with this email address, "+
+			"but you already have one. Log in here:\n\n%s\n\n"+
+			"If you forgot your password, you can reset it here:\n\n%s\n\n"+
+			"If this was not you, you can ignore this email.\n",
+		u.FirstName, a.baseURL+"/login", a.baseURL+"/forgot-password"))
+}
+
+// send mails u; a failure is Unavailable, as the account itself is fine
+func (a *Accounts) send(ctx context.Context, u *User, subject, body string) error {
+	err := a.mail.Send(ctx, mailer.Message{To: u.Email, Subject: subject, Body: body})
+	if err != nil {
+		return apperr.Wrap(apperr.Unavailable, err, "could not send email")
+	}
+	return nil
+}
+
+// link returns the absolute URL of path with token in its query
+func (a *Accounts) link(path, token string) string {
+	return a.baseURL + path + "?" + url.Values{"token": {token}}.Encode()
+}
+
+// checkPassword adds the rules for a new password to v
+func checkPassword(v *validate.Validator, password string) {
+	v.Check(len([]rune(password)) >= 8, "password", "must be at least 8 characters")
+	// bcrypt ignores anything past 72 bytes
+	v.Check(len(password) <= 72, "password", "must be at most 72 bytes")
+}
+
+// hours spells out a whole number of hours for an email
+func hours(d time.Duration) string {
+	if n := int(d.Hours()); n != 1 {
+		return fmt.Sprintf("%d hours", n)
+	}
+	return "1 hour"
+}
+
+// fingerprint identifies a password hash without revealing it
+func fingerprint(hash string) string {
+	sum := sha256.Sum256([]byte(hash))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,169 @@
+package user_test
+
+import (
+	"context"
+	"errors"
+	"go-breeders/internal/apperr"
+	"go-breeders/internal/mailer"
+	"go-breeders/internal/mailer/mailertest"
+	"go-breeders/internal/signed"
+	"go-breeders/internal/user"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// linkRE finds the link in an account email
+var linkRE = regexp.MustCompile(`https://breeders\.example\.com(/[a-z-]+)\?(\S+)`)
+
+// mailedLink returns the path and token of the link in the last email to to
+func mailedLink(t *testing.T, outbox *mailertest.Outbox, to string) (string, string) {
+	t.Helper()
+	msg, ok := outbox.Last()
+	if !ok || msg.To != to {
+		t.Fatalf("expected an email to %s, got %+v", to, msg)
+	}
+	m := linkRE.FindStringSubmatch(msg.Body)
+	if m == nil {
+		t.Fatalf("no link in %q", msg.Body)
+	}
+	query, err := url.ParseQuery(m[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m[1], query.Get("token")
+}
+
+func TestAccounts_RegisterAndActivate(t *testing.T) {
+	ctx := context.Background()
+	repo := user.NewMemoryRepository(user.MockFixtures())
+	outbox := &mailertest.Outbox{}
+	accounts := user.NewAccounts(repo, signed.New([]byte("key")), outbox, "https://breeders.example.com/")
+	users := user.NewService(repo, 0, user.CookieConfig{})
+
+	err := accounts.Register(ctx, user.Registration{FirstName: "Jo", LastName: "Lee", Email: "ADMIN@example.com", Password: "short"})
+	var e *apperr.Error
+	if !apperr.Is(err, apperr.Validation) || !errors.As(err, &e) || len(e.Fields) != 1 || e.Fields[0].Field != "password" {
+		t.Fatalf("expected a password error, got %v", err)
+	}
+
+	// a taken email looks like a new one to the visitor; the owner is told by email
+	if err := accounts.Register(ctx, user.Registration{FirstName: "Jo", LastName: "Lee", Email: "ADMIN@example.com", Password: "long enough"}); err != nil {
+		t.Fatalf("expected a taken email to be accepted silently, got %v", err)
+	}
+	if msg, ok := outbox.Last(); !ok || msg.To != "admin@example.com" || !strings.Contains(msg.Body, "https://breeders.example.com/forgot-password") {
+		t.Fatalf("expected the account owner to be told, got %+v", msg)
+	}
+
+	if err := accounts.Register(ctx, user.Registration{FirstName: "Jo", LastName: "Lee", Email: "jo@example.com", Password: "long enough"}); err != nil {
+		t.Fatal(err)
+	}
+	u, err := repo.GetUserByEmail(ctx, "jo@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Active() || u.Role() != user.Viewer {
+		t.Errorf("expected an inactive viewer, got %+v", u)
+	}
+	if _, _, err := users.Login(ctx, "jo@example.com", "long enough"); !apperr.Is(err, apperr.Unauthorized) {
+		t.Errorf("expected an inactive account not to log in, got %v", err)
+	}
+
+	path, token := mailedLink(t, outbox, "jo@example.com")
+	if path != "/activate" {
+		t.Errorf("expected an activation link, got %s", path)
+	}
+	if _, err := accounts.CheckResetToken(ctx, token); !apperr.Is(err, apperr.BadRequest) {
+		t.Errorf("expected an activation token to be no reset token, got %v", err)
+	}
+	for range 2 {
+		if u, err := accounts.Activate(ctx, token); err != nil || !u.Active() {
+			t.Fatalf("Activate = %+v, %v", u, err)
+		}
+	}
+	if _, _, err := users.Login(ctx, "jo@example.com", "long enough"); err != nil {
+		t.Errorf("expected an activated account to log in, got %v", err)
+	}
+}
+
+func TestAccounts_PasswordReset(t *testing.T) {
+	ctx := context.Background()
+	repo := user.NewMemoryRepository(user.MockFixtures())
+	outbox := &mailertest.Outbox{}
+	accounts := user.NewAccounts(repo, signed.New([]byte("key")), outbox, "https://breeders.example.com")
+	users := user.NewService(repo, 0, user.CookieConfig{})
+
+	if err := accounts.RequestPasswordReset(ctx, "nobody@example.com"); err != nil || len(outbox.Messages()) != 0 {
+		t.Fatalf("expected unknown emails to be ignored silently, got %v and %d emails", err, len(outbox.Messages()))
+	}
+
+	session, _, err := users.Login(ctx, "admin@example.com", "verysecret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := accounts.RequestPasswordReset(ctx, "Admin@Example.com"); err != nil {
+		t.Fatal(err)
+	}
+	path, token := mailedLink(t, outbox, "admin@example.com")
+	if path != "/reset-password" {
+		t.Errorf("expected a reset link, got %s", path)
+	}
+
+	if err := accounts.ResetPassword(ctx, token, "short"); !apperr.Is(err, apperr.Validation) {
+		t.Errorf("expected a short password to be rejected, got %v", err)
+	}
+	if err := accounts.ResetPassword(ctx, token, "a new secret"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := users.UserForSession(ctx, session); !apperr.Is(err, apperr.Unauthorized) {
+		t.Errorf("expected the reset to end existing sessions, got %v", err)
+	}
+	if err := accounts.ResetPassword(ctx, token, "another secret"); !apperr.Is(err, apperr.BadRequest) {
+		t.Errorf("expected a used link to be rejected, got %v", err)
+	}
+	if _, err := users.Authenticate(ctx, "admin@example.com", "a new secret"); err != nil {
+		t.Errorf("expected the new password to work, got %v", err)
+	}
+}
+
+// failingSender is a mailer.Sender whose mail server is down
+type failingSender struct{}
+
+func (failingSender) Send(context.Context, mailer.Message) error {
+	return errors.New("connection refused")
+}
+
+func TestAccounts_PasswordResetSendFailure(t *testing.T) {
+	repo := user.NewMemoryRepository(user.MockFixtures())
+	accounts := user.NewAccounts(repo, signed.New([]byte("key")), failingSender{}, "https://breeders.example.com")
+
+	// a known account must answer like an unknown one, or the error gives it away
+	for _, email := range []string{"admin@example.com", "nobody@example.com"} {
+		if err := accounts.RequestPasswordReset(context.Background(), email); err != nil {
+			t.Errorf("%s: expected no error, got %v", email, err)
+		}
+	}
+}
+
+func TestAccounts_RegisterSendFailure(t *testing.T) {
+	ctx := context.Background()
+	repo := user.NewMemoryRepository(user.MockFixtures())
+	reg := user.Registration{FirstName: "Jo", LastName: "Lee", Email: "jo@example.com", Password: "long enough"}
+
+	// the account is kept even though its activation email is lost
+	failing := user.NewAccounts(repo, signed.New([]byte("key")), failingSender{}, "https://breeders.example.com")
+	if err := failing.Register(ctx, reg); err != nil {
+		t.Fatalf("expected the send failure to be logged only, got %v", err)
+	}
+
+	// signing up again sends a new activation link rather than getting stuck
+	outbox := &mailertest.Outbox{}
+	accounts := user.NewAccounts(repo, signed.New([]byte("key")), outbox, "https://breeders.example.com")
+	if err := accounts.Register(ctx, reg); err != nil {
+		t.Fatal(err)
+	}
+	if path, _ := mailedLink(t, outbox, "jo@example.com"); path != "/activate" {
+		t.Errorf("expected a new activation link, got %s", path)
+	}
+}
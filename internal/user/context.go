@@ -0,0 +1,98 @@
+package user
+
+import (
+	"context"
+	"go-breeders/internal/apperr"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// CookieName is the cookie that carries the session token
+const CookieName = "session"
+
+// contextKey and tokenKey keep this package's context values apart from everyone else's
+type (
+	contextKey struct{}
+	tokenKey   struct{}
+)
+
+// WithUser returns a copy of ctx that carries u
+func WithUser(ctx context.Context, u *User) context.Context {
+	return context.WithValue(ctx, contextKey{}, u)
+}
+
+// FromContext returns the logged-in user stored by LoadSession, or nil
+func FromContext(ctx context.Context) *User {
+	u, _ := ctx.Value(contextKey{}).(*User)
+	return u
+}
+
+// WithToken returns a copy of ctx that records the API token the request
+// authenticated with
+func WithToken(ctx context.Context, t *Token) context.Context {
+	return context.WithValue(ctx, tokenKey{}, t)
+}
+
+// TokenFromContext returns the API token stored by LoadToken, or nil for
+// session users and anonymous requests
+func TokenFromContext(ctx context.Context) *Token {
+	t, _ := ctx.Value(tokenKey{}).(*Token)
+	return t
+}
+
+// LoadToken is middleware that authenticates requests carrying an
+// "Authorization: Bearer" API token, replacing any session user. A request
+// with a bad token is refused with 401 rather than served anonymously.
+func (s *Service) LoadToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if auth == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		scheme, secret, ok := strings.Cut(auth, " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			apperr.WriteJSON(w, apperr.New(apperr.Unauthorized, "Authorization must be a Bearer token"))
+			return
+		}
+
+		u, t, err := s.UserForToken(r.Context(), strings.TrimSpace(secret))
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			apperr.WriteJSON(w, err)
+			return
+		}
+
+		ctx := WithToken(WithUser(r.Context(), u), t)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// LoadSession is middleware that looks up the session cookie and, when it
+// belongs to a valid session, stores the user in the request context.
+// Requests without a valid session carry on anonymously.
+func (s *Service) LoadSession(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := r.Cookie(CookieName)
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		u, err := s.UserForSession(r.Context(), c.Value)
+		if err != nil {
+			if apperr.Is(err, apperr.Unauthorized) {
+				s.ClearCookie(w, r)
+			} else {
+				log.Println("error loading session:", err)
+			}
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(WithUser(r.Context(), u)))
+	})
+}
@@ -0,0 +1,95 @@
+package user
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// SecureMode says when cookies get the Secure attribute
+type SecureMode int
+
+const (
+	// SecureAuto marks cookies Secure on requests that arrived over TLS,
+	// directly or through a proxy that sets X-Forwarded-Proto
+	SecureAuto SecureMode = iota
+	SecureAlways
+	SecureNever
+)
+
+// CookieConfig sets the attributes of the cookies the site sets. They are
+// always HttpOnly. The zero value uses SameSite=Lax and SecureAuto.
+type CookieConfig struct {
+	SameSite http.SameSite
+	Secure   SecureMode
+}
+
+// Cookie returns a cookie for the whole site with c's attributes. Without
+// an expiry it lasts until the browser closes. Browsers drop SameSite=None
+// cookies that are not Secure, so those always are.
+func (c CookieConfig) Cookie(r *http.Request, name, value string, expiry time.Time) *http.Cookie {
+	sameSite := c.SameSite
+	if sameSite == 0 {
+		sameSite = http.SameSiteLaxMode
+	}
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		Expires:  expiry,
+		HttpOnly: true,
+		Secure:   c.secure(r) || sameSite == http.SameSiteNoneMode,
+		SameSite: sameSite,
+	}
+}
+
+// secure reports whether cookies set in response to r should be Secure
+func (c CookieConfig) secure(r *http.Request) bool {
+	switch c.Secure {
+	case SecureAlways:
+		return true
+	case SecureNever:
+		return false
+	default:
+		return r.TLS != nil || strings.EqualFold(r.Header.Get("X-Forwarded-Proto"), "https")
+	}
+}
+
+// ParseSameSite parses "lax", "strict" or "none"
+func ParseSameSite(s string) (http.SameSite, error) {
+	switch strings.ToLower(s) {
+	case "lax":
+		return http.SameSiteLaxMode, nil
+	case "strict":
+		return http.SameSiteStrictMode, nil
+	case "none":
+		return http.SameSiteNoneMode, nil
+	}
+	return 0, fmt.Errorf("invalid SameSite %q: want lax, strict or none", s)
+}
+
+// ParseSecureMode parses "auto", "always" or "never"
+func ParseSecureMode(s string) (SecureMode, error) {
+	switch strings.ToLower(s) {
+	case "auto":
+		return SecureAuto, nil
+	case "always":
+		return SecureAlways, nil
+	case "never":
+		return SecureNever, nil
+	}
+	return 0, fmt.Errorf("invalid cookie security %q: want auto, always or never", s)
+}
+
+// SetCookie sends the session token to the browser
+func (s *Service) SetCookie(w http.ResponseWriter, r *http.Request, token string, expiry time.Time) {
+	http.SetCookie(w, s.cookies.Cookie(r, CookieName, token, expiry))
+}
+
+// ClearCookie tells the browser to drop the session cookie
+func (s *Service) ClearCookie(w http.ResponseWriter, r *http.Request) {
+	c := s.cookies.Cookie(r, CookieName, "", time.Time{})
+	c.MaxAge = -1
+	http.SetCookie(w, c)
+}
@@ -0,0 +1,43 @@
+package user
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCookieConfig_Cookie(t *testing.T) {
+	plain := httptest.NewRequest("GET", "/", nil)
+	overTLS := httptest.NewRequest("GET", "/", nil)
+	overTLS.TLS = &tls.ConnectionState{}
+	proxied := httptest.NewRequest("GET", "/", nil)
+	proxied.Header.Set("X-Forwarded-Proto", "https")
+
+	tests := []struct {
+		name             string
+		config           CookieConfig
+		r                *http.Request
+		expectedSecure   bool
+		expectedSameSite http.SameSite
+	}{
+		{"defaults", CookieConfig{}, plain, false, http.SameSiteLaxMode},
+		{"tls", CookieConfig{}, overTLS, true, http.SameSiteLaxMode},
+		{"behind proxy", CookieConfig{}, proxied, true, http.SameSiteLaxMode},
+		{"always", CookieConfig{Secure: SecureAlways}, plain, true, http.SameSiteLaxMode},
+		{"never", CookieConfig{Secure: SecureNever}, overTLS, false, http.SameSiteLaxMode},
+		{"strict", CookieConfig{SameSite: http.SameSiteStrictMode}, plain, false, http.SameSiteStrictMode},
+		{"none needs secure", CookieConfig{SameSite: http.SameSiteNoneMode, Secure: SecureNever}, plain, true, http.SameSiteNoneMode},
+	}
+
+	for _, tt := range tests {
+		c := tt.config.Cookie(tt.r, CookieName, "x", time.Time{})
+		if !c.HttpOnly || c.Path != "/" {
+			t.Errorf("%s: expected an HttpOnly cookie for /, got %+v", tt.name, c)
+		}
+		if c.Secure != tt.expectedSecure || c.SameSite != tt.expectedSameSite {
+			t.Errorf("%s: got Secure=%v SameSite=%v, want %v %v", tt.name, c.Secure, c.SameSite, tt.expectedSecure, tt.expectedSameSite)
+		}
+	}
+}
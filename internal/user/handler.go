@@ -0,0 +1,179 @@
+package user
+
+import (
+	"go-breeders/internal/apperr"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/tsawler/toolbox"
+)
+
+// maxJSONSize caps the login request body (1 MB)
+const maxJSONSize = 1 << 20
+
+// Handler handles HTTP requests for the user domain
+type Handler struct {
+	service *Service
+}
+
+// NewHandler creates a new user handler
+func NewHandler(service *Service) *Handler {
+	return &Handler{service: service}
+}
+
+// credentials is the JSON body of a login request
+type credentials struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// LoginJSON logs in with the email and password in the JSON body and sets
+// the session cookie. The body must be sent as application/json, which
+// other sites' forms cannot do, so they cannot log a visitor in.
+func (h *Handler) LoginJSON(w http.ResponseWriter, r *http.Request) {
+	t := toolbox.Tools{MaxJSONSize: maxJSONSize}
+
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/json" {
+		apperr.WriteJSON(w, apperr.New(apperr.BadRequest, "Content-Type must be application/json"))
+		return
+	}
+
+	var c credentials
+	if err := t.ReadJSON(w, r, &c); err != nil {
+		apperr.WriteJSON(w, apperr.New(apperr.BadRequest, err.Error()))
+		return
+	}
+
+	token, session, err := h.service.Login(r.Context(), c.Email, c.Password)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+	h.service.SetCookie(w, r, token, session.Expiry)
+
+	u, err := h.service.GetUserByID(r.Context(), session.UserID)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+	_ = t.WriteJSON(w, http.StatusOK, u)
+}
+
+// LogoutJSON ends the current session and clears the cookie
+func (h *Handler) LogoutJSON(w http.ResponseWriter, r *http.Request) {
+	if c, err := r.Cookie(CookieName); err == nil {
+		if err := h.service.Logout(r.Context(), c.Value); err != nil {
+			apperr.WriteJSON(w, err)
+			return
+		}
+	}
+	h.service.ClearCookie(w, r)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// MeJSON returns the logged-in user as JSON
+func (h *Handler) MeJSON(w http.ResponseWriter, r *http.Request) {
+	var t toolbox.Tools
+
+	u := FromContext(r.Context())
+	if u == nil {
+		apperr.WriteJSON(w, errNoSession)
+		return
+	}
+	_ = t.WriteJSON(w, http.StatusOK, u)
+}
+
+// tokenRequest is the JSON body of a request to create an API token
+type tokenRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// issuedToken is a new API token with its secret, which is never shown again
+type issuedToken struct {
+	Secret string `json:"token"`
+	*Token
+}
+
+// CreateTokenJSON issues an API token for the logged-in user
+func (h *Handler) CreateTokenJSON(w http.ResponseWriter, r *http.Request) {
+	t := toolbox.Tools{MaxJSONSize: maxJSONSize}
+
+	u, err := sessionUser(r)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	var req tokenRequest
+	if err := t.ReadJSON(w, r, &req); err != nil {
+		apperr.WriteJSON(w, apperr.New(apperr.BadRequest, err.Error()))
+		return
+	}
+
+	secret, token, err := h.service.CreateToken(r.Context(), u, req.Name, req.Scopes, req.ExpiresAt)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	_ = t.WriteJSON(w, http.StatusCreated, issuedToken{Secret: secret, Token: token})
+}
+
+// ListTokensJSON returns the logged-in user's API tokens as JSON
+func (h *Handler) ListTokensJSON(w http.ResponseWriter, r *http.Request) {
+	var t toolbox.Tools
+
+	u, err := sessionUser(r)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	tokens, err := h.service.ListTokens(r.Context(), u.ID)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	_ = t.WriteJSON(w, http.StatusOK, tokens)
+}
+
+// RevokeTokenJSON deletes the logged-in user's API token identified by the {id} URL param
+func (h *Handler) RevokeTokenJSON(w http.ResponseWriter, r *http.Request) {
+	u, err := sessionUser(r)
+	if err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id < 1 {
+		apperr.WriteJSON(w, apperr.New(apperr.BadRequest, "invalid id"))
+		return
+	}
+
+	if err := h.service.RevokeToken(r.Context(), u.ID, id); err != nil {
+		apperr.WriteJSON(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// sessionUser returns the user logged in with a session. API tokens may not
+// manage tokens, so a leaked token cannot be used to mint more.
+func sessionUser(r *http.Request) (*User, error) {
+	u := FromContext(r.Context())
+	if u == nil {
+		return nil, errNoSession
+	}
+	if TokenFromContext(r.Context()) != nil {
+		return nil, apperr.New(apperr.Forbidden, "API tokens cannot manage API tokens")
+	}
+	return u, nil
+}
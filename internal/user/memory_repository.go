@@ -0,0 +1,302 @@
+package user
+
+import (
+	"context"
+	"go-breeders/internal/apperr"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryRepository is a thread-safe in-memory implementation of Repository.
+// It stores copies, so callers may modify what they pass in or get back.
+type MemoryRepository struct {
+	mu          sync.RWMutex
+	users       map[int]*User
+	nextUserID  int
+	sessions    map[string]*Session
+	tokens      map[int]*Token
+	nextTokenID int
+}
+
+// NewMemoryRepository creates an in-memory repository for users seeded with fixtures
+func NewMemoryRepository(fixtures []*User) Repository {
+	r := &MemoryRepository{
+		users:       make(map[int]*User),
+		nextUserID:  1,
+		sessions:    make(map[string]*Session),
+		tokens:      make(map[int]*Token),
+		nextTokenID: 1,
+	}
+	for _, u := range fixtures {
+		user := *u
+		r.users[u.ID] = &user
+		r.nextUserID = max(r.nextUserID, u.ID+1)
+	}
+	return r
+}
+
+// MockFixtures returns the seeded admin account (password "verysecret").
+// The hash uses the minimum bcrypt cost so tests stay fast.
+func MockFixtures() []*User {
+	return []*User{
+		{
+			ID:          1,
+			FirstName:   "Admin",
+			LastName:    "User",
+			Email:       "admin@example.com",
+			Password:    "$2a$04$Yx97OiGSIia65AAooP.8Q.yDuCBo12UWyiKttEM3IIdL5L.zLa.J.",
+			UserActive:  1,
+			AccessLevel: 30,
+		},
+	}
+}
+
+// GetUserByID returns a single user by ID
+func (r *MemoryRepository) GetUserByID(ctx context.Context, id int) (*User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	u, ok := r.users[id]
+	if !ok {
+		return nil, apperr.NotFoundf("user %d not found", id)
+	}
+	user := *u
+	return &user, nil
+}
+
+// GetUserByEmail returns the user with email, ignoring case
+func (r *MemoryRepository) GetUserByEmail(ctx context.Context, email string) (*User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, u := range r.users {
+		if strings.EqualFold(u.Email, email) {
+			user := *u
+			return &user, nil
+		}
+	}
+	return nil, apperr.NotFoundf("user not found")
+}
+
+// InsertUser stores a copy of user under the next ID and returns the ID.
+// Emails are unique, ignoring case.
+func (r *MemoryRepository) InsertUser(ctx context.Context, user *User) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, u := range r.users {
+		if strings.EqualFold(u.Email, user.Email) {
+			return 0, apperr.New(apperr.Conflict, "user already exists")
+		}
+	}
+	u := *user
+	u.ID = r.nextUserID
+	r.users[u.ID] = &u
+	r.nextUserID++
+
+	return u.ID, nil
+}
+
+// UpdatePassword replaces a user's password hash, or returns NotFound
+func (r *MemoryRepository) UpdatePassword(ctx context.Context, id int, hash string) error {
+	return r.updateUser(id, func(u *User) { u.Password = hash })
+}
+
+// ActivateUser marks a user active, or returns NotFound
+func (r *MemoryRepository) ActivateUser(ctx context.Context, id int) error {
+	return r.updateUser(id, func(u *User) { u.UserActive = 1 })
+}
+
+// updateUser replaces user id with a copy changed by update
+func (r *MemoryRepository) updateUser(id int, update func(*User)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	u, ok := r.users[id]
+	if !ok {
+		return apperr.NotFoundf("user %d not found", id)
+	}
+	user := *u
+	update(&user)
+	r.users[id] = &user
+
+	return nil
+}
+
+// InsertSession stores a copy of session
+func (r *MemoryRepository) InsertSession(ctx context.Context, session *Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.users[session.UserID]; !ok {
+		return apperr.New(apperr.Validation, "session references a record that does not exist")
+	}
+	if _, ok := r.sessions[session.TokenHash]; ok {
+		return apperr.New(apperr.Conflict, "session already exists")
+	}
+	s := *session
+	r.sessions[s.TokenHash] = &s
+
+	return nil
+}
+
+// GetSession returns the session with tokenHash, expired or not
+func (r *MemoryRepository) GetSession(ctx context.Context, tokenHash string) (*Session, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	s, ok := r.sessions[tokenHash]
+	if !ok {
+		return nil, apperr.NotFoundf("session not found")
+	}
+	session := *s
+	return &session, nil
+}
+
+// DeleteSession deletes a session, or returns NotFound
+func (r *MemoryRepository) DeleteSession(ctx context.Context, tokenHash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.sessions[tokenHash]; !ok {
+		return apperr.NotFoundf("session not found")
+	}
+	delete(r.sessions, tokenHash)
+
+	return nil
+}
+
+// DeleteExpiredSessions deletes every session that expired before now
+func (r *MemoryRepository) DeleteExpiredSessions(ctx context.Context, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	maps.DeleteFunc(r.sessions, func(_ string, s *Session) bool {
+		return s.Expiry.Before(now)
+	})
+	return nil
+}
+
+// DeleteUserSessions logs a user out everywhere
+func (r *MemoryRepository) DeleteUserSessions(ctx context.Context, userID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	maps.DeleteFunc(r.sessions, func(_ string, s *Session) bool {
+		return s.UserID == userID
+	})
+	return nil
+}
+
+// InsertToken stores a copy of token under the next ID and returns the ID
+func (r *MemoryRepository) InsertToken(ctx context.Context, token *Token) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.users[token.UserID]; !ok {
+		return 0, apperr.New(apperr.Validation, "API token references a record that does not exist")
+	}
+	for _, t := range r.tokens {
+		if t.TokenHash == token.TokenHash {
+			return 0, apperr.New(apperr.Conflict, "API token already exists")
+		}
+	}
+	t := copyToken(token)
+	t.ID = r.nextTokenID
+	r.tokens[t.ID] = t
+	r.nextTokenID++
+
+	return t.ID, nil
+}
+
+// TokensForUser returns the user's API tokens, oldest first
+func (r *MemoryRepository) TokensForUser(ctx context.Context, userID int) ([]*Token, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tokens := []*Token{}
+	for _, t := range r.tokens {
+		if t.UserID == userID {
+			tokens = append(tokens, copyToken(t))
+		}
+	}
+	slices.SortFunc(tokens, func(a, b *Token) int { return a.ID - b.ID })
+	return tokens, nil
+}
+
+// GetTokenByHash returns the API token with tokenHash, expired or not
+func (r *MemoryRepository) GetTokenByHash(ctx context.Context, tokenHash string) (*Token, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, t := range r.tokens {
+		if t.TokenHash == tokenHash {
+			return copyToken(t), nil
+		}
+	}
+	return nil, apperr.NotFoundf("API token not found")
+}
+
+// DeleteToken deletes one of the user's API tokens, or returns NotFound
+func (r *MemoryRepository) DeleteToken(ctx context.Context, userID, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if t, ok := r.tokens[id]; !ok || t.UserID != userID {
+		return apperr.NotFoundf("API token not found")
+	}
+	delete(r.tokens, id)
+
+	return nil
+}
+
+// TouchToken records when an API token was last used
+func (r *MemoryRepository) TouchToken(ctx context.Context, id int, usedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t, ok := r.tokens[id]
+	if !ok {
+		return apperr.NotFoundf("API token not found")
+	}
+	// replaced rather than modified, so snapshots keep the old value
+	touched := copyToken(t)
+	touched.LastUsedAt = &usedAt
+	r.tokens[id] = touched
+
+	return nil
+}
+
+// copyToken returns a copy of t that shares no memory with it
+func copyToken(t *Token) *Token {
+	c := *t
+	c.Scopes = slices.Clone(t.Scopes)
+	if t.ExpiresAt != nil {
+		expiresAt := *t.ExpiresAt
+		c.ExpiresAt = &expiresAt
+	}
+	if t.LastUsedAt != nil {
+		lastUsedAt := *t.LastUsedAt
+		c.LastUsedAt = &lastUsedAt
+	}
+	return &c
+}
+
+// Snapshot records the repository's contents and returns a function that puts
+// them back, so a unit of work can undo its writes
+func (r *MemoryRepository) Snapshot() (restore func()) {
+	r.mu.RLock()
+	users, nextUserID, sessions := maps.Clone(r.users), r.nextUserID, maps.Clone(r.sessions)
+	tokens, nextTokenID := maps.Clone(r.tokens), r.nextTokenID
+	r.mu.RUnlock()
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.users, r.nextUserID, r.sessions = users, nextUserID, sessions
+		r.tokens, r.nextTokenID = tokens, nextTokenID
+	}
+}
@@ -0,0 +1,40 @@
+package user
+
+import "time"
+
+// User is an account that can log in to the site
+type User struct {
+	ID          int    `json:"id"`
+	FirstName   string `json:"first_name"`
+	LastName    string `json:"last_name"`
+	Email       string `json:"email"`
+	Password    string `json:"-"` // bcrypt hash, never sent to clients
+	UserActive  int    `json:"user_active"`
+	AccessLevel int    `json:"access_level"`
+}
+
+// Active reports whether the account may log in
+func (u *User) Active() bool {
+	return u.UserActive == 1
+}
+
+// Session is a logged-in browser. The cookie carries a random token and
+// only its hash is stored.
+type Session struct {
+	TokenHash string
+	UserID    int
+	Expiry    time.Time
+}
+
+// Token is an API token a user issued for a machine client. The client
+// sends the token as a bearer credential; only its hash is stored.
+type Token struct {
+	ID         int        `json:"id"`
+	UserID     int        `json:"-"`
+	Name       string     `json:"name"`
+	TokenHash  string     `json:"-"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`   // nil never expires
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"` // nil until first used
+	CreatedAt  time.Time  `json:"created_at"`
+}
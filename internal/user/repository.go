@@ -0,0 +1,30 @@
+package user
+
+import (
+	"context"
+	"time"
+)
+
+// Repository defines the interface for user, session and API token data operations
+type Repository interface {
+	// User operations
+	GetUserByID(ctx context.Context, id int) (*User, error)
+	GetUserByEmail(ctx context.Context, email string) (*User, error)
+	InsertUser(ctx context.Context, user *User) (int, error)
+	UpdatePassword(ctx context.Context, id int, hash string) error
+	ActivateUser(ctx context.Context, id int) error
+
+	// Session operations
+	InsertSession(ctx context.Context, session *Session) error
+	GetSession(ctx context.Context, tokenHash string) (*Session, error)
+	DeleteSession(ctx context.Context, tokenHash string) error
+	DeleteExpiredSessions(ctx context.Context, now time.Time) error
+	DeleteUserSessions(ctx context.Context, userID int) error
+
+	// API token operations
+	InsertToken(ctx context.Context, token *Token) (int, error)
+	TokensForUser(ctx context.Context, userID int) ([]*Token, error)
+	GetTokenByHash(ctx context.Context, tokenHash string) (*Token, error)
+	DeleteToken(ctx context.Context, userID, id int) error
+	TouchToken(ctx context.Context, id int, usedAt time.Time) error
+}
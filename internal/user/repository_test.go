@@ -0,0 +1,166 @@
+package user_test
+
+import (
+	"context"
+	"go-breeders/internal/apperr"
+	"go-breeders/internal/database/databasetest"
+	"go-breeders/internal/user"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMemoryRepository(t *testing.T) {
+	testRepository(t, user.NewMemoryRepository(user.MockFixtures()))
+}
+
+func TestSQLiteRepository(t *testing.T) {
+	testRepository(t, user.NewSQLiteRepository(databasetest.SQLite(t), 0))
+}
+
+func TestMySQLRepository(t *testing.T) {
+	testRepository(t, user.NewMySQLRepository(databasetest.MySQL(t), 0))
+}
+
+func TestPostgresRepository(t *testing.T) {
+	testRepository(t, user.NewPostgresRepository(databasetest.Postgres(t), 0))
+}
+
+// testRepository checks a repository seeded with the admin account
+func testRepository(t *testing.T, repo user.Repository) {
+	ctx := context.Background()
+
+	admin, err := repo.GetUserByEmail(ctx, "ADMIN@example.com")
+	if err != nil {
+		t.Fatal("email lookups should ignore case:", err)
+	}
+	if admin.AccessLevel != 30 || !admin.Active() || !strings.HasPrefix(admin.Password, "$2a$") {
+		t.Errorf("unexpected admin %+v", admin)
+	}
+
+	byID, err := repo.GetUserByID(ctx, admin.ID)
+	if err != nil || byID.Email != admin.Email {
+		t.Errorf("GetUserByID(%d) = %+v, %v", admin.ID, byID, err)
+	}
+	if _, err := repo.GetUserByID(ctx, 1_000_000); !apperr.Is(err, apperr.NotFound) {
+		t.Errorf("expected NotFound for a missing user, got %v", err)
+	}
+	if _, err := repo.GetUserByEmail(ctx, "nobody@example.com"); !apperr.Is(err, apperr.NotFound) {
+		t.Errorf("expected NotFound for a missing email, got %v", err)
+	}
+
+	taken := &user.User{FirstName: "Second", LastName: "Admin", Email: "Admin@Example.com", Password: admin.Password, AccessLevel: 10}
+	if _, err := repo.InsertUser(ctx, taken); !apperr.Is(err, apperr.Conflict) {
+		t.Errorf("expected Conflict for a registered email in another case, got %v", err)
+	}
+	if err := repo.UpdatePassword(ctx, admin.ID, "$2a$04$changed"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = repo.UpdatePassword(ctx, admin.ID, admin.Password) })
+	if u, _ := repo.GetUserByID(ctx, admin.ID); u == nil || u.Password != "$2a$04$changed" {
+		t.Errorf("expected the password to change, got %+v", u)
+	}
+	if err := repo.ActivateUser(ctx, admin.ID); err != nil {
+		t.Errorf("activating an active user: %v", err)
+	}
+	if err := repo.ActivateUser(ctx, 1_000_000); !apperr.Is(err, apperr.NotFound) {
+		t.Errorf("expected NotFound activating a missing user, got %v", err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	live := &user.Session{TokenHash: strings.Repeat("a", 64), UserID: admin.ID, Expiry: now.Add(time.Hour)}
+	expired := &user.Session{TokenHash: strings.Repeat("b", 64), UserID: admin.ID, Expiry: now.Add(-time.Hour)}
+	for _, s := range []*user.Session{live, expired} {
+		if err := repo.InsertSession(ctx, s); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { _ = repo.DeleteSession(ctx, s.TokenHash) })
+	}
+
+	got, err := repo.GetSession(ctx, live.TokenHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.UserID != admin.ID || !got.Expiry.Equal(live.Expiry) {
+		t.Errorf("GetSession = %+v, want %+v", got, live)
+	}
+	if err := repo.InsertSession(ctx, live); !apperr.Is(err, apperr.Conflict) {
+		t.Errorf("expected Conflict for a duplicate token, got %v", err)
+	}
+	orphan := &user.Session{TokenHash: strings.Repeat("c", 64), UserID: 1_000_000, Expiry: live.Expiry}
+	if err := repo.InsertSession(ctx, orphan); !apperr.Is(err, apperr.Validation) {
+		t.Errorf("expected Validation for a missing user, got %v", err)
+	}
+
+	if err := repo.DeleteExpiredSessions(ctx, now); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.GetSession(ctx, expired.TokenHash); !apperr.Is(err, apperr.NotFound) {
+		t.Errorf("expected the expired session to be deleted, got %v", err)
+	}
+	if _, err := repo.GetSession(ctx, live.TokenHash); err != nil {
+		t.Errorf("expected the live session to survive, got %v", err)
+	}
+
+	if err := repo.DeleteSession(ctx, live.TokenHash); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.DeleteSession(ctx, live.TokenHash); !apperr.Is(err, apperr.NotFound) {
+		t.Errorf("expected NotFound deleting twice, got %v", err)
+	}
+
+	if err := repo.InsertSession(ctx, live); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.DeleteUserSessions(ctx, admin.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.GetSession(ctx, live.TokenHash); !apperr.Is(err, apperr.NotFound) {
+		t.Errorf("expected the user's sessions to be deleted, got %v", err)
+	}
+
+	expiresAt := now.Add(24 * time.Hour)
+	token := &user.Token{
+		UserID:    admin.ID,
+		Name:      "intake script",
+		TokenHash: strings.Repeat("d", 64),
+		Scopes:    []string{user.ScopeRead, user.ScopeWrite},
+		ExpiresAt: &expiresAt,
+		CreatedAt: now,
+	}
+	id, err := repo.InsertToken(ctx, token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = repo.DeleteToken(ctx, admin.ID, id) })
+
+	gotToken, err := repo.GetTokenByHash(ctx, token.TokenHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotToken.ID != id || gotToken.Name != token.Name || len(gotToken.Scopes) != 2 || gotToken.Scopes[1] != user.ScopeWrite ||
+		gotToken.ExpiresAt == nil || !gotToken.ExpiresAt.Equal(expiresAt) || gotToken.LastUsedAt != nil || !gotToken.CreatedAt.Equal(now) {
+		t.Errorf("GetTokenByHash = %+v, want %+v", gotToken, token)
+	}
+
+	if err := repo.TouchToken(ctx, id, now.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	tokens, err := repo.TokensForUser(ctx, admin.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tokens) != 1 || tokens[0].LastUsedAt == nil || !tokens[0].LastUsedAt.Equal(now.Add(time.Minute)) {
+		t.Errorf("expected one token used at %v, got %+v", now.Add(time.Minute), tokens)
+	}
+
+	if err := repo.DeleteToken(ctx, admin.ID+1, id); !apperr.Is(err, apperr.NotFound) {
+		t.Errorf("expected NotFound revoking another user's token, got %v", err)
+	}
+	if err := repo.DeleteToken(ctx, admin.ID, id); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.GetTokenByHash(ctx, token.TokenHash); !apperr.Is(err, apperr.NotFound) {
+		t.Errorf("expected a revoked token to be gone, got %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package user
+
+// Role is what a user may do, derived from their access_level
+type Role int
+
+// Roles in increasing order of privilege. Their values are the lowest
+// access_level that grants them.
+const (
+	Viewer Role = 10 // may read
+	Staff  Role = 20 // breeder staff: may also manage breeders, dogs and cats
+	Admin  Role = 30 // may also reach the admin pages
+)
+
+// RoleFor maps an access_level to a role; anything below Staff is a Viewer
+func RoleFor(accessLevel int) Role {
+	switch {
+	case accessLevel >= int(Admin):
+		return Admin
+	case accessLevel >= int(Staff):
+		return Staff
+	default:
+		return Viewer
+	}
+}
+
+// String returns the role's name
+func (r Role) String() string {
+	switch r {
+	case Admin:
+		return "admin"
+	case Staff:
+		return "staff"
+	default:
+		return "viewer"
+	}
+}
+
+// Role returns the user's role
+func (u *User) Role() Role {
+	return RoleFor(u.AccessLevel)
+}
+
+// Can reports whether the user holds role or a more privileged one
+func (u *User) Can(role Role) bool {
+	return u.Role() >= role
+}
+
+// API token scopes. Each grants the role of the same rank, but never more
+// than the token's owner holds.
+const (
+	ScopeRead  = "read"
+	ScopeWrite = "write"
+	ScopeAdmin = "admin"
+)
+
+// scopeRoles maps each scope to the role it grants
+var scopeRoles = map[string]Role{
+	ScopeRead:  Viewer,
+	ScopeWrite: Staff,
+	ScopeAdmin: Admin,
+}
+
+// Role returns the most privileged role the token's scopes grant
+func (t *Token) Role() Role {
+	role := Viewer
+	for _, scope := range t.Scopes {
+		role = max(role, scopeRoles[scope])
+	}
+	return role
+}
@@ -0,0 +1,299 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go-breeders/internal/apperr"
+	"go-breeders/internal/validate"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// PasswordCost is the bcrypt cost of new password hashes
+const PasswordCost = 12
+
+// DefaultSessionLifetime is how long a login lasts when no lifetime is configured
+const DefaultSessionLifetime = 24 * time.Hour
+
+// errBadLogin is returned for every failed login, so callers cannot tell an
+// unknown email from a wrong password
+var errBadLogin = apperr.New(apperr.Unauthorized, "invalid email or password")
+
+// errNoSession is returned when a session token is missing, unknown or expired
+var errNoSession = apperr.New(apperr.Unauthorized, "not logged in")
+
+// errBadToken is returned when an API token is unknown, expired or revoked
+var errBadToken = apperr.New(apperr.Unauthorized, "invalid API token")
+
+// TokenPrefix starts every API token, so leaked tokens are easy to recognise
+const TokenPrefix = "brd_"
+
+// tokenTouchInterval limits how often a token's last-used time is written
+const tokenTouchInterval = time.Minute
+
+// dummyHash is compared against when the email is unknown, so that a failed
+// lookup takes as long as a wrong password
+var dummyHash = sync.OnceValue(func() []byte {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("not a password"), PasswordCost)
+	return hash
+})
+
+// Service provides business logic for accounts and login sessions
+type Service struct {
+	repo     Repository
+	lifetime time.Duration
+	cookies  CookieConfig
+	now      func() time.Time
+}
+
+// NewService creates a new user service. Sessions last lifetime; zero uses
+// DefaultSessionLifetime. Session cookies get the attributes in cookies.
+func NewService(repo Repository, lifetime time.Duration, cookies CookieConfig) *Service {
+	if lifetime <= 0 {
+		lifetime = DefaultSessionLifetime
+	}
+	return &Service{repo: repo, lifetime: lifetime, cookies: cookies, now: time.Now}
+}
+
+// HashPassword returns the bcrypt hash stored for password
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), PasswordCost)
+	if err != nil {
+		return "", apperr.New(apperr.Validation, err.Error())
+	}
+	return string(hash), nil
+}
+
+// GetUserByID returns a specific user
+func (s *Service) GetUserByID(ctx context.Context, id int) (*User, error) {
+	return s.repo.GetUserByID(ctx, id)
+}
+
+// Authenticate returns the active user with email and password, or an
+// Unauthorized error that does not say which part was wrong
+func (s *Service) Authenticate(ctx context.Context, email, password string) (*User, error) {
+	u, err := s.repo.GetUserByEmail(ctx, email)
+	if apperr.Is(err, apperr.NotFound) {
+		_ = bcrypt.CompareHashAndPassword(dummyHash(), []byte(password))
+		return nil, errBadLogin
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return nil, errBadLogin
+	}
+	if err != nil {
+		return nil, apperr.Wrap(apperr.Internal, err, "checking password")
+	}
+	if !u.Active() {
+		return nil, errBadLogin
+	}
+	return u, nil
+}
+
+// Login authenticates the user and starts a session. The returned token
+// goes in the session cookie; only its hash is stored.
+func (s *Service) Login(ctx context.Context, email, password string) (string, *Session, error) {
+	u, err := s.Authenticate(ctx, email, password)
+	if err != nil {
+		return "", nil, err
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return "", nil, err
+	}
+
+	now := s.now().UTC().Truncate(time.Second)
+	session := &Session{
+		TokenHash: hashToken(token),
+		UserID:    u.ID,
+		Expiry:    now.Add(s.lifetime),
+	}
+	if err := s.repo.InsertSession(ctx, session); err != nil {
+		return "", nil, err
+	}
+
+	// expired sessions are otherwise never removed; a failure here must not
+	// stop the login
+	if err := s.repo.DeleteExpiredSessions(ctx, now); err != nil {
+		log.Println("error deleting expired sessions:", err)
+	}
+	return token, session, nil
+}
+
+// Logout ends the session for token; an unknown token is not an error
+func (s *Service) Logout(ctx context.Context, token string) error {
+	err := s.repo.DeleteSession(ctx, hashToken(token))
+	if apperr.Is(err, apperr.NotFound) {
+		return nil
+	}
+	return err
+}
+
+// UserForSession returns the user logged in with token, or an Unauthorized
+// error when the session is unknown or expired or the account is inactive
+func (s *Service) UserForSession(ctx context.Context, token string) (*User, error) {
+	if token == "" {
+		return nil, errNoSession
+	}
+
+	session, err := s.repo.GetSession(ctx, hashToken(token))
+	if apperr.Is(err, apperr.NotFound) {
+		return nil, errNoSession
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !s.now().Before(session.Expiry) {
+		return nil, errNoSession
+	}
+
+	u, err := s.repo.GetUserByID(ctx, session.UserID)
+	if apperr.Is(err, apperr.NotFound) {
+		return nil, errNoSession
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !u.Active() {
+		return nil, errNoSession
+	}
+	return u, nil
+}
+
+// CreateToken issues an API token for u. The returned secret is shown to
+// the user once; only its hash is stored.
+func (s *Service) CreateToken(ctx context.Context, u *User, name string, scopes []string, expiresAt *time.Time) (string, *Token, error) {
+	now := s.now().UTC().Truncate(time.Second)
+
+	v := validate.New()
+	v.Required("name", name)
+	v.MaxLength("name", name, 255)
+	v.Check(len(scopes) > 0, "scopes", "is required")
+	for _, scope := range scopes {
+		role, ok := scopeRoles[scope]
+		v.Check(ok, "scopes", fmt.Sprintf("%q is not a scope", scope))
+		v.Check(!ok || u.Can(role), "scopes", fmt.Sprintf("%q needs %s access", scope, role))
+	}
+	if expiresAt != nil {
+		v.Check(expiresAt.After(now), "expires_at", "must be in the future")
+	}
+	if err := v.Err(); err != nil {
+		return "", nil, err
+	}
+
+	secret, err := newToken()
+	if err != nil {
+		return "", nil, err
+	}
+	secret = TokenPrefix + secret
+
+	token := &Token{
+		UserID:    u.ID,
+		Name:      name,
+		TokenHash: hashToken(secret),
+		Scopes:    normalizeScopes(scopes),
+		CreatedAt: now,
+	}
+	if expiresAt != nil {
+		expiry := expiresAt.UTC().Truncate(time.Second)
+		token.ExpiresAt = &expiry
+	}
+
+	id, err := s.repo.InsertToken(ctx, token)
+	if err != nil {
+		return "", nil, err
+	}
+	token.ID = id
+	return secret, token, nil
+}
+
+// ListTokens returns the user's API tokens
+func (s *Service) ListTokens(ctx context.Context, userID int) ([]*Token, error) {
+	return s.repo.TokensForUser(ctx, userID)
+}
+
+// RevokeToken deletes one of the user's API tokens; other users' tokens are NotFound
+func (s *Service) RevokeToken(ctx context.Context, userID, id int) error {
+	return s.repo.DeleteToken(ctx, userID, id)
+}
+
+// UserForToken returns the user an API token acts for, and the token. The
+// user's access level is lowered to what the token's scopes grant, so role
+// checks apply to token and session users alike.
+func (s *Service) UserForToken(ctx context.Context, secret string) (*User, *Token, error) {
+	if !strings.HasPrefix(secret, TokenPrefix) {
+		return nil, nil, errBadToken
+	}
+
+	token, err := s.repo.GetTokenByHash(ctx, hashToken(secret))
+	if apperr.Is(err, apperr.NotFound) {
+		return nil, nil, errBadToken
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	now := s.now()
+	if token.ExpiresAt != nil && !now.Before(*token.ExpiresAt) {
+		return nil, nil, errBadToken
+	}
+
+	u, err := s.repo.GetUserByID(ctx, token.UserID)
+	if apperr.Is(err, apperr.NotFound) {
+		return nil, nil, errBadToken
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if !u.Active() {
+		return nil, nil, errBadToken
+	}
+	u.AccessLevel = min(u.AccessLevel, int(token.Role()))
+
+	// a busy client would otherwise write on every request
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= tokenTouchInterval {
+		usedAt := now.UTC().Truncate(time.Second)
+		if err := s.repo.TouchToken(ctx, token.ID, usedAt); err != nil {
+			log.Println("error recording API token use:", err)
+		} else {
+			token.LastUsedAt = &usedAt
+		}
+	}
+	return u, token, nil
+}
+
+// normalizeScopes sorts scopes from least to most privileged and drops duplicates
+func normalizeScopes(scopes []string) []string {
+	scopes = slices.Clone(scopes)
+	slices.SortFunc(scopes, func(a, b string) int { return int(scopeRoles[a] - scopeRoles[b]) })
+	return slices.Compact(scopes)
+}
+
+// newToken returns 32 random bytes, URL-safe base64 encoded
+func newToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", apperr.Wrap(apperr.Internal, err, "generating session token")
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashToken returns the hex SHA-256 of token, the form tokens are stored in
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
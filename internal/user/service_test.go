@@ -0,0 +1,116 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"go-breeders/internal/apperr"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestService_LoginSession(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository(MockFixtures())
+	s := NewService(repo, time.Hour, CookieConfig{})
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+
+	for _, tc := range []struct{ email, password string }{
+		{"admin@example.com", "wrong"},
+		{"nobody@example.com", "verysecret"},
+	} {
+		if _, _, err := s.Login(ctx, tc.email, tc.password); !apperr.Is(err, apperr.Unauthorized) {
+			t.Errorf("Login(%q, %q): expected Unauthorized, got %v", tc.email, tc.password, err)
+		}
+	}
+
+	token, session, err := s.Login(ctx, "Admin@Example.com", "verysecret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if session.TokenHash == token || !session.Expiry.Equal(now.Add(time.Hour)) {
+		t.Errorf("unexpected session %+v for token %q", session, token)
+	}
+
+	u, err := s.UserForSession(ctx, token)
+	if err != nil || u.ID != 1 {
+		t.Fatalf("UserForSession = %+v, %v", u, err)
+	}
+
+	now = now.Add(time.Hour)
+	if _, err := s.UserForSession(ctx, token); !apperr.Is(err, apperr.Unauthorized) {
+		t.Errorf("expected an expired session to be Unauthorized, got %v", err)
+	}
+
+	// the next login sweeps the expired session away
+	now = now.Add(time.Second)
+	if _, _, err := s.Login(ctx, "admin@example.com", "verysecret"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.GetSession(ctx, session.TokenHash); !apperr.Is(err, apperr.NotFound) {
+		t.Errorf("expected the expired session to be deleted, got %v", err)
+	}
+
+	if err := s.Logout(ctx, token); err != nil {
+		t.Errorf("logging out of a deleted session: %v", err)
+	}
+}
+
+func TestService_InactiveUser(t *testing.T) {
+	fixtures := MockFixtures()
+	fixtures[0].UserActive = 0
+	s := NewService(NewMemoryRepository(fixtures), 0, CookieConfig{})
+
+	if _, err := s.Authenticate(context.Background(), "admin@example.com", "verysecret"); !apperr.Is(err, apperr.Unauthorized) {
+		t.Errorf("expected an inactive user to be Unauthorized, got %v", err)
+	}
+}
+
+func TestService_Tokens(t *testing.T) {
+	ctx := context.Background()
+	fixtures := append(MockFixtures(), &User{ID: 2, Email: "staff@example.com", UserActive: 1, AccessLevel: int(Staff)})
+	s := NewService(NewMemoryRepository(fixtures), 0, CookieConfig{})
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return now }
+	staff, _ := s.GetUserByID(ctx, 2)
+
+	past := now.Add(-time.Hour)
+	_, _, err := s.CreateToken(ctx, staff, "", []string{ScopeAdmin, "delete"}, &past)
+	var e *apperr.Error
+	if !errors.As(err, &e) || e.Kind != apperr.Validation || len(e.Fields) != 4 {
+		t.Fatalf("expected 4 field errors (name, admin, delete, expires_at), got %v", err)
+	}
+
+	expiry := now.Add(time.Hour)
+	secret, token, err := s.CreateToken(ctx, staff, "intake", []string{ScopeWrite, ScopeRead, ScopeWrite}, &expiry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(secret, TokenPrefix) || !slices.Equal(token.Scopes, []string{ScopeRead, ScopeWrite}) {
+		t.Errorf("unexpected token %q %+v", secret, token)
+	}
+
+	u, used, err := s.UserForToken(ctx, secret)
+	if err != nil || u.ID != 2 || used.LastUsedAt == nil || !used.LastUsedAt.Equal(now) {
+		t.Fatalf("UserForToken = %+v, %+v, %v", u, used, err)
+	}
+
+	readOnly, _, err := s.CreateToken(ctx, staff, "reports", []string{ScopeRead}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, _, err = s.UserForToken(ctx, readOnly)
+	if err != nil || u.Role() != Viewer {
+		t.Errorf("expected a read token to act as a viewer, got %+v, %v", u, err)
+	}
+
+	now = expiry
+	if _, _, err := s.UserForToken(ctx, secret); !apperr.Is(err, apperr.Unauthorized) {
+		t.Errorf("expected an expired token to be Unauthorized, got %v", err)
+	}
+	if err := s.RevokeToken(ctx, 1, token.ID); !apperr.Is(err, apperr.NotFound) {
+		t.Errorf("expected NotFound revoking another user's token, got %v", err)
+	}
+}
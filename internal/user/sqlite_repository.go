@@ -0,0 +1,215 @@
+package user
+
+import (
+	"context"
+	"go-breeders/internal/apperr"
+	"go-breeders/internal/database"
+	"strings"
+	"time"
+)
+
+// SQLiteRepository is the SQLite implementation of Repository
+type SQLiteRepository struct {
+	DB           database.DBTX // a *sql.DB, or a *sql.Tx inside a unit of work
+	QueryTimeout time.Duration // per-query deadline, on top of the caller's context
+}
+
+// NewSQLiteRepository creates a new SQLite repository for users.
+// queryTimeout bounds each query; zero uses the 3 second default.
+func NewSQLiteRepository(db database.DBTX, queryTimeout time.Duration) Repository {
+	if queryTimeout <= 0 {
+		queryTimeout = defaultQueryTimeout
+	}
+	return &SQLiteRepository{DB: db, QueryTimeout: queryTimeout}
+}
+
+// withTimeout derives the context for one query, as in MySQLRepository
+func (r *SQLiteRepository) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, r.QueryTimeout)
+}
+
+// GetUserByID returns a single user by ID
+func (r *SQLiteRepository) GetUserByID(ctx context.Context, id int) (*User, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = ?`
+	return scanUser(r.DB.QueryRowContext(ctx, query, id))
+}
+
+// GetUserByEmail returns the user with email, ignoring case
+func (r *SQLiteRepository) GetUserByEmail(ctx context.Context, email string) (*User, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + userColumns + ` FROM users WHERE email = ? COLLATE NOCASE`
+	return scanUser(r.DB.QueryRowContext(ctx, query, email))
+}
+
+// InsertUser stores a new user and returns its ID
+func (r *SQLiteRepository) InsertUser(ctx context.Context, user *User) (int, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `INSERT INTO users (first_name, last_name, email, password, user_active, access_level)
+			VALUES (?, ?, ?, ?, ?, ?)`
+
+	result, err := r.DB.ExecContext(ctx, query,
+		user.FirstName, user.LastName, user.Email, user.Password, user.UserActive, user.AccessLevel,
+	)
+	if err != nil {
+		return 0, apperr.FromSQL(err, "user")
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, apperr.FromSQL(err, "user")
+	}
+
+	return int(id), nil
+}
+
+// UpdatePassword replaces a user's password hash, or returns NotFound
+func (r *SQLiteRepository) UpdatePassword(ctx context.Context, id int, hash string) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	result, err := r.DB.ExecContext(ctx, `UPDATE users SET password = ? WHERE id = ?`, hash, id)
+	return apperr.FromResult(result, err, "user")
+}
+
+// ActivateUser marks a user active, or returns NotFound
+func (r *SQLiteRepository) ActivateUser(ctx context.Context, id int) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	result, err := r.DB.ExecContext(ctx, `UPDATE users SET user_active = 1 WHERE id = ?`, id)
+	return apperr.FromResult(result, err, "user")
+}
+
+// InsertSession stores a new session
+func (r *SQLiteRepository) InsertSession(ctx context.Context, session *Session) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `INSERT INTO sessions (token_hash, user_id, expiry) VALUES (?, ?, ?)`
+	_, err := r.DB.ExecContext(ctx, query, session.TokenHash, session.UserID, session.Expiry)
+	return apperr.FromSQL(err, "session")
+}
+
+// GetSession returns the session with tokenHash, expired or not
+func (r *SQLiteRepository) GetSession(ctx context.Context, tokenHash string) (*Session, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT token_hash, user_id, expiry FROM sessions WHERE token_hash = ?`
+	return scanSession(r.DB.QueryRowContext(ctx, query, tokenHash))
+}
+
+// DeleteSession deletes a session, or returns NotFound
+func (r *SQLiteRepository) DeleteSession(ctx context.Context, tokenHash string) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `DELETE FROM sessions WHERE token_hash = ?`
+	result, err := r.DB.ExecContext(ctx, query, tokenHash)
+	return apperr.FromResult(result, err, "session")
+}
+
+// DeleteExpiredSessions deletes every session that expired before now
+func (r *SQLiteRepository) DeleteExpiredSessions(ctx context.Context, now time.Time) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	_, err := r.DB.ExecContext(ctx, `DELETE FROM sessions WHERE expiry < ?`, now)
+	return apperr.FromSQL(err, "session")
+}
+
+// DeleteUserSessions logs a user out everywhere
+func (r *SQLiteRepository) DeleteUserSessions(ctx context.Context, userID int) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	_, err := r.DB.ExecContext(ctx, `DELETE FROM sessions WHERE user_id = ?`, userID)
+	return apperr.FromSQL(err, "session")
+}
+
+// InsertToken stores a new API token and returns its ID
+func (r *SQLiteRepository) InsertToken(ctx context.Context, token *Token) (int, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `INSERT INTO api_tokens (user_id, name, token_hash, scopes, expires_at, created_at)
+			VALUES (?, ?, ?, ?, ?, ?)`
+
+	result, err := r.DB.ExecContext(ctx, query,
+		token.UserID, token.Name, token.TokenHash, strings.Join(token.Scopes, ","),
+		token.ExpiresAt, token.CreatedAt,
+	)
+	if err != nil {
+		return 0, apperr.FromSQL(err, "API token")
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, apperr.FromSQL(err, "API token")
+	}
+
+	return int(id), nil
+}
+
+// TokensForUser returns the user's API tokens, oldest first
+func (r *SQLiteRepository) TokensForUser(ctx context.Context, userID int) ([]*Token, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + tokenColumns + ` FROM api_tokens WHERE user_id = ? ORDER BY id`
+	rows, err := r.DB.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, apperr.FromSQL(err, "API token")
+	}
+	defer rows.Close()
+
+	tokens := []*Token{}
+	for rows.Next() {
+		t, err := scanToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, apperr.FromSQL(err, "API token")
+	}
+
+	return tokens, nil
+}
+
+// GetTokenByHash returns the API token with tokenHash, expired or not
+func (r *SQLiteRepository) GetTokenByHash(ctx context.Context, tokenHash string) (*Token, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + tokenColumns + ` FROM api_tokens WHERE token_hash = ?`
+	return scanToken(r.DB.QueryRowContext(ctx, query, tokenHash))
+}
+
+// DeleteToken deletes one of the user's API tokens, or returns NotFound
+func (r *SQLiteRepository) DeleteToken(ctx context.Context, userID, id int) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `DELETE FROM api_tokens WHERE id = ? AND user_id = ?`
+	result, err := r.DB.ExecContext(ctx, query, id, userID)
+	return apperr.FromResult(result, err, "API token")
+}
+
+// TouchToken records when an API token was last used
+func (r *SQLiteRepository) TouchToken(ctx context.Context, id int, usedAt time.Time) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `UPDATE api_tokens SET last_used_at = ? WHERE id = ?`
+	result, err := r.DB.ExecContext(ctx, query, usedAt, id)
+	return apperr.FromResult(result, err, "API token")
+}
@@ -0,0 +1,106 @@
+package validate
+
+import (
+	"fmt"
+	"go-breeders/internal/apperr"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// phoneRE accepts digits with optional leading +, spaces, dots, dashes and parentheses
+var phoneRE = regexp.MustCompile(`^\+?[0-9 ().-]{7,20}$`)
+
+// Validator collects field errors so every problem is reported in one response
+type Validator struct {
+	fields []apperr.FieldError
+}
+
+// New creates an empty validator
+func New() *Validator {
+	return &Validator{}
+}
+
+// Check adds message for field when ok is false
+func (v *Validator) Check(ok bool, field, message string) {
+	if !ok {
+		v.fields = append(v.fields, apperr.FieldError{Field: field, Message: message})
+	}
+}
+
+// Required checks that value is not blank
+func (v *Validator) Required(field, value string) {
+	v.Check(strings.TrimSpace(value) != "", field, "is required")
+}
+
+// MaxLength checks that value has at most n characters
+func (v *Validator) MaxLength(field, value string, n int) {
+	v.Check(len([]rune(value)) <= n, field, "is too long")
+}
+
+// Email checks that value, when present, is a bare email address
+func (v *Validator) Email(field, value string) {
+	if value == "" {
+		return
+	}
+	addr, err := mail.ParseAddress(value)
+	v.Check(err == nil && addr.Address == value, field, "must be a valid email address")
+}
+
+// Phone checks that value, when present, looks like a phone number
+func (v *Validator) Phone(field, value string) {
+	if value == "" {
+		return
+	}
+	v.Check(phoneRE.MatchString(value), field, "must be a valid phone number")
+}
+
+// Past checks that t is set and not in the future
+func (v *Validator) Past(field string, t time.Time) {
+	if t.IsZero() {
+		v.Check(false, field, "is required")
+		return
+	}
+	v.Check(!t.After(time.Now()), field, "must not be in the future")
+}
+
+// OneOf checks that value is one of the allowed values
+func (v *Validator) OneOf(field string, value int, allowed ...int) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	v.Check(false, field, "has an invalid value")
+}
+
+// Valid reports whether no field errors were recorded
+func (v *Validator) Valid() bool {
+	return len(v.fields) == 0
+}
+
+// Err returns a Validation error listing every field error, or nil
+func (v *Validator) Err() error {
+	if v.Valid() {
+		return nil
+	}
+	return &apperr.Error{
+		Kind:    apperr.Validation,
+		Message: "validation failed",
+		Fields:  v.fields,
+	}
+}
+
+// WithinTolerance checks that value lies in [low, high] widened by tolerance
+// (a fraction of the range bounds). A zero high bound means the range is
+// unknown and the check is skipped.
+func (v *Validator) WithinTolerance(field string, value, low, high int, tolerance float64) {
+	if high == 0 {
+		return
+	}
+	lo := int(float64(low) * (1 - tolerance))
+	hi := int(float64(high)*(1+tolerance) + 0.5)
+	v.Check(value >= lo && value <= hi, field,
+		fmt.Sprintf("must be between %d and %d for this breed", lo, hi))
+}
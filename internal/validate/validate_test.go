@@ -0,0 +1,149 @@
+package validate
+
+import (
+	"errors"
+	"go-breeders/internal/apperr"
+	"testing"
+	"time"
+)
+
+func TestValidator_Email(t *testing.T) {
+	tests := []struct {
+		value string
+		valid bool
+	}{
+		{"", true},
+		{"jo@example.com", true},
+		{"jo.lee+dogs@mail.example.co.uk", true},
+		{"jo@example", true},
+		{"jo", false},
+		{"jo@", false},
+		{"@example.com", false},
+		{"Jo Lee <jo@example.com>", false},
+		{" jo@example.com", false},
+	}
+
+	for _, tt := range tests {
+		v := New()
+		v.Email("email", tt.value)
+		if v.Valid() != tt.valid {
+			t.Errorf("%q: got valid=%v, want %v", tt.value, v.Valid(), tt.valid)
+		}
+	}
+}
+
+func TestValidator_Phone(t *testing.T) {
+	tests := []struct {
+		value string
+		valid bool
+	}{
+		{"", true},
+		{"902-555-0100", true},
+		{"+1 (902) 555.0100", true},
+		{"5550100", true},
+		{"555010", false},
+		{"902-555-CALL", false},
+		{"+1 902 555 0100 0100 0100", false},
+		{"1+902", false},
+	}
+
+	for _, tt := range tests {
+		v := New()
+		v.Phone("phone", tt.value)
+		if v.Valid() != tt.valid {
+			t.Errorf("%q: got valid=%v, want %v", tt.value, v.Valid(), tt.valid)
+		}
+	}
+}
+
+func TestValidator_Past(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   time.Time
+		message string
+	}{
+		{"past", time.Now().AddDate(-1, 0, 0), ""},
+		{"now", time.Now(), ""},
+		{"future", time.Now().Add(time.Hour), "must not be in the future"},
+		{"missing", time.Time{}, "is required"},
+	}
+
+	for _, tt := range tests {
+		v := New()
+		v.Past("date_of_birth", tt.value)
+		if got := message(v); got != tt.message {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.message)
+		}
+	}
+}
+
+func TestValidator_MaxLength(t *testing.T) {
+	tests := []struct {
+		value string
+		valid bool
+	}{
+		{"", true},
+		{"Rex", true},
+		{"Fido!", true},
+		{"Fido!!", false},
+		{"Günté", true}, // five characters, seven bytes
+	}
+
+	for _, tt := range tests {
+		v := New()
+		v.MaxLength("name", tt.value, 5)
+		if v.Valid() != tt.valid {
+			t.Errorf("%q: got valid=%v, want %v", tt.value, v.Valid(), tt.valid)
+		}
+	}
+}
+
+func TestValidator_WithinTolerance(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     int
+		low, high int
+		message   string
+	}{
+		{"inside", 60, 55, 80, ""},
+		{"lowest allowed", 44, 55, 80, ""},
+		{"below", 43, 55, 80, "must be between 44 and 96 for this breed"},
+		{"highest allowed", 96, 55, 80, ""},
+		{"above", 97, 55, 80, "must be between 44 and 96 for this breed"},
+		{"unknown range", 500, 0, 0, ""},
+		{"no lower bound", 1, 0, 10, ""},
+	}
+
+	for _, tt := range tests {
+		v := New()
+		v.WithinTolerance("weight", tt.value, tt.low, tt.high, 0.2)
+		if got := message(v); got != tt.message {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.message)
+		}
+	}
+}
+
+func TestValidator_Err(t *testing.T) {
+	v := New()
+	if err := v.Err(); err != nil {
+		t.Fatalf("expected no error from an empty validator, got %v", err)
+	}
+
+	v.Required("name", " ")
+	v.Email("email", "nope")
+	var e *apperr.Error
+	if err := v.Err(); !errors.As(err, &e) || e.Kind != apperr.Validation || len(e.Fields) != 2 {
+		t.Fatalf("expected a Validation error with 2 fields, got %v", err)
+	}
+	if e.Fields[0].Field != "name" || e.Fields[1].Field != "email" {
+		t.Errorf("fields out of order: %+v", e.Fields)
+	}
+}
+
+// message returns the single field error v recorded, or ""
+func message(v *Validator) string {
+	if len(v.fields) == 0 {
+		return ""
+	}
+	return v.fields[0].Message
+}
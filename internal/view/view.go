@@ -0,0 +1,172 @@
+// Package view parses the page templates and keeps them ready for rendering.
+package view
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"log"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PageSuffix ends the file name of every page template
+const PageSuffix = ".page.gohtml"
+
+// layoutPatterns match the templates parsed alongside every page
+var layoutPatterns = []string{"*.layout.gohtml", "partials/*.partial.gohtml"}
+
+// Manager parses page templates from a file system. With caching on it
+// parses every page once up front and serves them from memory; with caching
+// off it parses the requested page on every call, so edits show up at once.
+type Manager struct {
+	fsys  fs.FS
+	cache bool
+
+	mu    sync.RWMutex
+	pages map[string]*template.Template
+	stamp string // fingerprint of the files pages was parsed from
+}
+
+// New creates a manager for the templates in fsys. With cache set it parses
+// every page now, so a broken template stops startup instead of a request.
+func New(fsys fs.FS, cache bool) (*Manager, error) {
+	m := &Manager{fsys: fsys, cache: cache}
+	if cache {
+		if err := m.Reload(); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// Pages returns the sorted file names of the page templates
+func (m *Manager) Pages() ([]string, error) {
+	pages, err := fs.Glob(m.fsys, "*"+PageSuffix)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(pages)
+	return pages, nil
+}
+
+// Get returns the template set for page, e.g. "home.page.gohtml". An unknown
+// page gives an error wrapping fs.ErrNotExist.
+func (m *Manager) Get(page string) (*template.Template, error) {
+	if !strings.HasSuffix(page, PageSuffix) || path.Base(page) != page || !fs.ValidPath(page) {
+		return nil, fmt.Errorf("page %q: %w", page, fs.ErrNotExist)
+	}
+
+	if !m.cache {
+		if _, err := fs.Stat(m.fsys, page); err != nil {
+			return nil, fmt.Errorf("page %q: %w", page, err)
+		}
+		return m.parse(page)
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	tmpl, ok := m.pages[page]
+	if !ok {
+		return nil, fmt.Errorf("page %q: %w", page, fs.ErrNotExist)
+	}
+	return tmpl, nil
+}
+
+// Reload parses every page again and swaps the new set in. If any page
+// fails to parse the current set is kept.
+func (m *Manager) Reload() error {
+	stamp, err := m.fingerprint()
+	if err != nil {
+		return err
+	}
+
+	names, err := m.Pages()
+	if err != nil {
+		return err
+	}
+
+	pages := make(map[string]*template.Template, len(names))
+	for _, name := range names {
+		tmpl, err := m.parse(name)
+		if err != nil {
+			return err
+		}
+		pages[name] = tmpl
+	}
+
+	m.mu.Lock()
+	m.pages, m.stamp = pages, stamp
+	m.mu.Unlock()
+	return nil
+}
+
+// Watch checks the templates every interval and reloads them when a file
+// has been added, removed or modified since the last load, until ctx is
+// done. It is meant for development with an os.DirFS and a caching manager;
+// embedded templates never change.
+func (m *Manager) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	failed := "" // fingerprint of files that did not parse, to report them once
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		current, err := m.fingerprint()
+		if err != nil {
+			log.Println("error watching templates:", err)
+			continue
+		}
+		m.mu.RLock()
+		changed := current != m.stamp
+		m.mu.RUnlock()
+		if !changed || current == failed {
+			continue
+		}
+
+		if err := m.Reload(); err != nil {
+			failed = current
+			log.Println("error reloading templates, keeping the previous ones:", err)
+			continue
+		}
+		log.Println("templates reloaded")
+	}
+}
+
+// parse builds the template set for one page: the layouts, the partials and the page
+func (m *Manager) parse(page string) (*template.Template, error) {
+	patterns := append(append([]string{}, layoutPatterns...), page)
+
+	tmpl, err := template.ParseFS(m.fsys, patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", page, err)
+	}
+	return tmpl, nil
+}
+
+// fingerprint summarises the name, size and modification time of every template file
+func (m *Manager) fingerprint() (string, error) {
+	var b strings.Builder
+	err := fs.WalkDir(m.fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(p, ".gohtml") {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&b, "%s %d %d\n", p, info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	return b.String(), err
+}
@@ -0,0 +1,145 @@
+package view
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+// files returns a minimal template tree with the given home page body
+func files(home string) fstest.MapFS {
+	return fstest.MapFS{
+		"base.layout.gohtml":             {Data: []byte(`{{define "base"}}<main>{{template "header" .}}{{block "content" .}}{{end}}</main>{{end}}`)},
+		"partials/header.partial.gohtml": {Data: []byte(`{{define "header"}}<h1>Breeders</h1>{{end}}`)},
+		"home.page.gohtml":               {Data: []byte(`{{template "base" .}}{{define "content"}}` + home + `{{end}}`)},
+		"about.page.gohtml":              {Data: []byte(`{{template "base" .}}{{define "content"}}about{{end}}`)},
+		"partials/unused.partial.gohtml": {Data: []byte(`{{define "unused"}}{{end}}`)},
+		"notes.txt":                      {Data: []byte(`not a template`)},
+	}
+}
+
+func render(t *testing.T, m *Manager, page string) string {
+	t.Helper()
+	tmpl, err := m.Get(page)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b strings.Builder
+	if err := tmpl.ExecuteTemplate(&b, page, nil); err != nil {
+		t.Fatal(err)
+	}
+	return b.String()
+}
+
+func TestManager(t *testing.T) {
+	for _, cache := range []bool{true, false} {
+		fsys := files("welcome")
+		m, err := New(fsys, cache)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := render(t, m, "home.page.gohtml"); got != "<main><h1>Breeders</h1>welcome</main>" {
+			t.Errorf("cache=%v: rendered %q", cache, got)
+		}
+
+		for _, page := range []string{"missing.page.gohtml", "../home.page.gohtml", "partials/header.partial.gohtml", "home"} {
+			if _, err := m.Get(page); !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("cache=%v: expected %q not to exist, got %v", cache, page, err)
+			}
+		}
+
+		// only an uncached manager sees edits before Reload
+		fsys["home.page.gohtml"].Data = []byte(`{{template "base" .}}{{define "content"}}edited{{end}}`)
+		want := "edited"
+		if cache {
+			want = "welcome"
+		}
+		if got := render(t, m, "home.page.gohtml"); !strings.Contains(got, want) {
+			t.Errorf("cache=%v: expected %q, got %q", cache, want, got)
+		}
+	}
+}
+
+func TestManager_Pages(t *testing.T) {
+	m, err := New(files(""), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pages, err := m.Pages()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(pages, ",") != "about.page.gohtml,home.page.gohtml" {
+		t.Errorf("got pages %v", pages)
+	}
+}
+
+func TestManager_Reload(t *testing.T) {
+	fsys := files("welcome")
+	m, err := New(fsys, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fsys["home.page.gohtml"].Data = []byte(`{{template "base" .}}{{define "content"}}{{end`)
+	if err := m.Reload(); err == nil {
+		t.Fatal("expected a broken page to fail the reload")
+	}
+	if got := render(t, m, "home.page.gohtml"); !strings.Contains(got, "welcome") {
+		t.Errorf("expected the previous pages to be kept, got %q", got)
+	}
+
+	fsys["home.page.gohtml"].Data = []byte(`{{template "base" .}}{{define "content"}}fixed{{end}}`)
+	if err := m.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if got := render(t, m, "home.page.gohtml"); !strings.Contains(got, "fixed") {
+		t.Errorf("expected the reloaded page, got %q", got)
+	}
+
+	if _, err := New(fstest.MapFS{"bad.page.gohtml": {Data: []byte(`{{`)}}, true); err == nil {
+		t.Error("expected New to fail on a broken page in cache mode")
+	}
+}
+
+func TestManager_Watch(t *testing.T) {
+	dir := t.TempDir()
+	for name, file := range files("welcome") {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, file.Data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m, err := New(os.DirFS(dir), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go m.Watch(ctx, 10*time.Millisecond)
+
+	// the size changes too, so the edit is seen even on coarse mtimes
+	page := `{{template "base" .}}{{define "content"}}changed on disk{{end}}`
+	if err := os.WriteFile(filepath.Join(dir, "home.page.gohtml"), []byte(page), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !strings.Contains(render(t, m, "home.page.gohtml"), "changed on disk") {
+		if time.Now().After(deadline) {
+			t.Fatal("the change was not picked up")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
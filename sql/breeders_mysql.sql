@@ -15,6 +15,38 @@
 /*!40101 SET @OLD_SQL_MODE=@@SQL_MODE, SQL_MODE='NO_AUTO_VALUE_ON_ZERO' */;
 /*!40111 SET @OLD_SQL_NOTES=@@SQL_NOTES, SQL_NOTES=0 */;
 
+--
+-- Table structure for table `api_tokens`
+--
+
+DROP TABLE IF EXISTS `api_tokens`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `api_tokens` (
+  `id` int(11) unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` int(11) unsigned NOT NULL,
+  `name` varchar(255) NOT NULL,
+  `token_hash` char(64) NOT NULL,
+  `scopes` varchar(255) NOT NULL,
+  `expires_at` datetime DEFAULT NULL,
+  `last_used_at` datetime DEFAULT NULL,
+  `created_at` datetime NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `api_tokens_token_hash` (`token_hash`),
+  KEY `api_tokens_user_id` (`user_id`),
+  CONSTRAINT `api_tokens_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `api_tokens`
+--
+
+LOCK TABLES `api_tokens` WRITE;
+/*!40000 ALTER TABLE `api_tokens` DISABLE KEYS */;
+/*!40000 ALTER TABLE `api_tokens` ENABLE KEYS */;
+UNLOCK TABLES;
+
 --
 -- Table structure for table `breeders`
 --
@@ -24,6 +56,15 @@ DROP TABLE IF EXISTS `breeders`;
 /*!40101 SET character_set_client = utf8 */;
 CREATE TABLE `breeders` (
   `id` int(11) unsigned NOT NULL AUTO_INCREMENT,
+  `breeder_name` varchar(255) NOT NULL,
+  `address` varchar(255) NOT NULL DEFAULT '',
+  `city` varchar(255) NOT NULL DEFAULT '',
+  `prov_state` varchar(255) NOT NULL DEFAULT '',
+  `country` varchar(255) NOT NULL DEFAULT '',
+  `zip` varchar(20) NOT NULL DEFAULT '',
+  `phone` varchar(50) NOT NULL DEFAULT '',
+  `email` varchar(255) NOT NULL DEFAULT '',
+  `active` int(11) NOT NULL DEFAULT 1,
   PRIMARY KEY (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
 /*!40101 SET character_set_client = @saved_cs_client */;
@@ -263,7 +304,7 @@ INSERT INTO `dog_breeds` VALUES
 (455,'Collie',45,75,13,'','',''),
 (456,'Coton de Tulear',8,15,17,'','',''),
 (457,'Curly Coated Retriever',60,95,11,'','',''),
-(458,'Dachshund',1,11,14,'','',''),
+(458,'Dachshund',1,11,14,'','Sausage Dog, Wiener Dog',''),
 (459,'Dandie Dinmont Terrier',18,24,14,'','',''),
 (460,'Danish-Swedish Farmdog',15,20,12,'','',''),
 (461,'Doberman Pinscher',60,100,11,'','',''),
@@ -282,7 +323,7 @@ INSERT INTO `dog_breeds` VALUES
 (474,'French Bulldog',16,28,11,'','',''),
 (475,'German Longhaired Pointer',55,80,13,'','',''),
 (476,'German Pinscher',25,45,13,'','',''),
-(477,'German Shepherd Dog',48,90,13,'','',''),
+(477,'German Shepherd Dog',48,90,13,'','Alsatian',''),
 (478,'German Shorthaired Pointer',45,70,11,'','',''),
 (479,'German Wirehaired Pointer',50,70,15,'','',''),
 (480,'Giant Schnauzer',55,104,14,'','',''),
@@ -308,7 +349,7 @@ INSERT INTO `dog_breeds` VALUES
 (500,'Kerry Blue Terrier',33,45,14,'','Irish Blue Terrier',''),
 (501,'Komondor',80,135,11,'','',''),
 (502,'Kuvasz',70,115,11,'','',''),
-(503,'Labrador Retriever',55,80,11,'','',''),
+(503,'Labrador Retriever',55,80,11,'','Lab',''),
 (504,'Lakeland Terrier',15,18,14,'','',''),
 (505,'Lhasa Apso',12,18,14,'','Tibetan Apso ',''),
 (506,'Maltese',4,9,14,'','',''),
@@ -331,7 +372,7 @@ INSERT INTO `dog_breeds` VALUES
 (523,'Parson Russell Terrier',13,17,14,'','',''),
 (524,'Patterdale Terrier',10,17,12,'','Black Fell Terrier',''),
 (525,'Pekingese',6,14,13,'','','China'),
-(526,'Pembroke Welsh Corgi',20,30,13,'','','Wales'),
+(526,'Pembroke Welsh Corgi',20,30,13,'','Corgi','Wales'),
 (527,'Perro de Presa Canario',84,143,10,'','',''),
 (528,'Petit Basset Griffon Vendeen',30,45,13,'','',''),
 (529,'Pharaoh Hound',45,55,13,'','Kelb Tal-Fenek ','Malta'),
@@ -366,7 +407,7 @@ INSERT INTO `dog_breeds` VALUES
 (558,'Skye Terrier',18,40,13,'','','England'),
 (559,'Smooth Fox Terrier',15,18,14,'','','Ireland'),
 (560,'Spinone Italiano',62,86,11,'','Italian Coarse-Haired Pointer',''),
-(561,'Staffordshire Bull Terrier',24,38,13,'','','England'),
+(561,'Staffordshire Bull Terrier',24,38,13,'','Staffie','England'),
 (562,'Sussex Spaniel',35,51,14,'','','Germany'),
 (563,'Tibetan Mastiff',70,160,11,'','Do-Khyi ','England'),
 (564,'Tibetan Spaniel',9,15,14,'','','Tibet  '),
@@ -421,6 +462,34 @@ LOCK TABLES `dogs` WRITE;
 /*!40000 ALTER TABLE `dogs` ENABLE KEYS */;
 UNLOCK TABLES;
 
+--
+-- Table structure for table `sessions`
+--
+
+DROP TABLE IF EXISTS `sessions`;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE `sessions` (
+  `token_hash` char(64) NOT NULL,
+  `user_id` int(11) unsigned NOT NULL,
+  `expiry` datetime NOT NULL,
+  `created_at` datetime NOT NULL DEFAULT current_timestamp(),
+  PRIMARY KEY (`token_hash`),
+  KEY `sessions_expiry` (`expiry`),
+  KEY `sessions_user_id` (`user_id`),
+  CONSTRAINT `sessions_user_id` FOREIGN KEY (`user_id`) REFERENCES `users` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+--
+-- Dumping data for table `sessions`
+--
+
+LOCK TABLES `sessions` WRITE;
+/*!40000 ALTER TABLE `sessions` DISABLE KEYS */;
+/*!40000 ALTER TABLE `sessions` ENABLE KEYS */;
+UNLOCK TABLES;
+
 --
 -- Table structure for table `users`
 --
@@ -436,7 +505,8 @@ CREATE TABLE `users` (
   `password` varchar(60) NOT NULL,
   `user_active` int(11) NOT NULL DEFAULT 0,
   `access_level` int(11) NOT NULL DEFAULT 10,
-  PRIMARY KEY (`id`)
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `users_email` (`email`)
 ) ENGINE=InnoDB AUTO_INCREMENT=2 DEFAULT CHARSET=utf8mb4;
 /*!40101 SET character_set_client = @saved_cs_client */;
 
@@ -0,0 +1,10 @@
+// Package templates embeds the HTML templates, so the web binary runs
+// without the templates directory next to it
+package templates
+
+import "embed"
+
+// FS holds the layouts, partials and pages
+//
+//go:embed *.gohtml partials/*.gohtml
+var FS embed.FS